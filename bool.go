@@ -1,90 +1,22 @@
 package validator
 
-import (
-	"errors"
-	"fmt"
-)
-
-type boolAction struct {
-	validator      func() error
-	refinement     func(bool) error
-	transformer    func(bool) bool
-	code           string
-	refinementData RefinementData
-}
+import "context"
 
 type BoolField struct {
-	value         *bool
-	name          string
-	optional      bool
-	requiredError string
-	actions       []boolAction
-	abortEarly    bool
-}
-
-func (f *BoolField) addValidation(fn func() error, code string) {
-	action := boolAction{validator: fn, code: code}
-	f.actions = append(f.actions, action)
-}
-
-func (f *BoolField) addRefinement(fn func(bool) error, refinementData RefinementData) {
-	action := boolAction{refinement: fn, refinementData: refinementData}
-	f.actions = append(f.actions, action)
-}
-
-func (f *BoolField) addTransformer(fn func(bool) bool) {
-	action := boolAction{transformer: fn}
-	f.actions = append(f.actions, action)
+	fieldCore[bool]
 }
 
-func (f *BoolField) _parse(errs *[]Error) bool {
-	if f.value == nil {
-		if !f.optional {
-			*errs = append(*errs, requiredFieldErr(f.name, f.requiredError))
-			return false
-		}
-
-		return true
+func (f *BoolField) ParseTo(ctx context.Context, errs *Collector) bool {
+	value, ok, done := f.boundValue(errs)
+	if done {
+		return ok
 	}
 
-	isFieldParsedSuccessfully := true
-	for _, action := range f.actions {
-		isActionParsedSuccessfully := true
-
-		if action.validator != nil {
-			err := action.validator()
-			if err != nil {
-				isActionParsedSuccessfully = false
-				*errs = append(*errs, Error{Field: f.name, Message: err.Error(), Code: action.code})
-			}
-		} else if action.refinement != nil {
-			err := action.refinement(*f.value)
-			if err != nil {
-				isActionParsedSuccessfully = false
-				me := Error{Field: f.name, Message: err.Error(), Code: CodeRefinement}
-				if action.refinementData.Field != "" {
-					me.Field = action.refinementData.Field
-				}
-				if action.refinementData.Code != "" {
-					me.Code = action.refinementData.Code
-				}
-
-				*errs = append(*errs, me)
-			}
-		} else if action.transformer != nil {
-			*f.value = action.transformer(*f.value)
-			continue
-		}
-
-		if !isActionParsedSuccessfully {
-			isFieldParsedSuccessfully = false
-			if f.abortEarly {
-				return false
-			}
-		}
-	}
+	return f.ParseValueTo(ctx, value, errs)
+}
 
-	return isFieldParsedSuccessfully
+func (f *BoolField) ParseValueTo(ctx context.Context, value *bool, errs *Collector) bool {
+	return f.parse(ctx, value, errs)
 }
 
 // AbortEarly stops the parsing of the field on the first error
@@ -105,21 +37,41 @@ func (f *BoolField) RequiredError(message string) *BoolField {
 	return f
 }
 
+// Default sets the value of the field to 'value' when it is missing, before the rules run.
+// A value is missing when its Patch is absent or null, or when it is empty with EmptyAsMissing, see FromPatch.
+// A nil pointer can't hold the default, so it is still required unless the field is Optional.
+// A blank string of a Coerce field is missing, and the default is stored in its target.
+func (f *BoolField) Default(value bool) *BoolField {
+	return f.DefaultFunc(func() bool { return value })
+}
+
+// DefaultFunc is like Default, but calls 'fn' for the default every time the value is missing.
+func (f *BoolField) DefaultFunc(fn func() bool) *BoolField {
+	f.defaultValue = fn
+	return f
+}
+
+// Nullable accepts null values, a nil pointer or a null Patch, and skips the rules for them.
+// Without it, null counts as missing.
+func (f *BoolField) Nullable() *BoolField {
+	f.nullable = true
+	return f
+}
+
+// EmptyAsMissing treats the zero value of the field, or an empty slice or map, as missing.
+// It then gets the default, or is reported as required unless the field is Optional.
+func (f *BoolField) EmptyAsMissing() *BoolField {
+	f.emptyAsMissing = true
+	return f
+}
+
 // Is checks if the field value is equal to the provided boolean value
 func (f *BoolField) Is(value bool, message ...string) *BoolField {
-	fv := *f.value
 	code := CodeIs
 
-	validator := func() error {
+	validator := func(fv bool) error {
 		if value != fv {
-			var msg string
-			if len(message) > 0 {
-				msg = message[0]
-			} else {
-				msg = fmt.Sprintf("%s should be %t", f.name, value)
-			}
-
-			return errors.New(msg)
+			return ruleMessage(message, CodeIs, f.name, map[string]any{"expected": value})
 		}
 		return nil
 	}
@@ -128,8 +80,56 @@ func (f *BoolField) Is(value bool, message ...string) *BoolField {
 	return f
 }
 
+// When adds the rules of 'then' to the field, but only applies them if 'cond' returns true when the field is parsed.
+// 'then' receives a new field with the same name to add the rules to.
+func (f *BoolField) When(cond func() bool, then func(*BoolField)) *BoolField {
+	sub := Bool(nil, f.name)
+	then(sub)
+
+	f.dependent = true
+	f.addNested(func(ctx context.Context, value *bool, errs *Collector) bool {
+		return !cond() || sub.ParseValueTo(ctx, value, errs)
+	})
+	return f
+}
+
+// Unless is like When, but applies the rules of 'then' only if 'cond' returns false
+func (f *BoolField) Unless(cond func() bool, then func(*BoolField)) *BoolField {
+	return f.When(func() bool { return !cond() }, then)
+}
+
+// RequiredIf makes the field required if 'cond' returns true when the field is parsed, and optional otherwise.
+// Nil and zero values count as missing, and the rules of a missing optional field are skipped.
+func (f *BoolField) RequiredIf(cond func() bool) *BoolField {
+	f.presence.requireIf(cond)
+	return f
+}
+
+// RequiredUnless makes the field required if 'cond' returns false, see RequiredIf
+func (f *BoolField) RequiredUnless(cond func() bool) *BoolField {
+	return f.RequiredIf(func() bool { return !cond() })
+}
+
+// RequiredWith makes the field required if any of the values pointed to by 'others' is present, see RequiredIf
+func (f *BoolField) RequiredWith(others ...any) *BoolField {
+	return f.RequiredIf(func() bool { return anyPresent(others) })
+}
+
+// ExcludedWith reports the field with CodeExcluded if it is present while any of the values pointed to by 'others' is.
+// The rules of the field are skipped while it is excluded.
+func (f *BoolField) ExcludedWith(others ...any) *BoolField {
+	f.presence.excludeIf(func() bool { return anyPresent(others) })
+	return f
+}
+
 // Refine lets you provide custom validation logic
 func (f *BoolField) Refine(fn func(bool) error, refinementData ...RefinementData) *BoolField {
+	return f.RefineCtx(func(_ context.Context, value bool) error { return fn(value) }, refinementData...)
+}
+
+// RefineCtx is like Refine, but 'fn' also receives the context passed to ParseCtx.
+// An error returned after the context is done is not reported as a refinement error.
+func (f *BoolField) RefineCtx(fn func(context.Context, bool) error, refinementData ...RefinementData) *BoolField {
 	var newRefinementData RefinementData
 	if len(refinementData) > 0 {
 		newRefinementData = refinementData[0]
@@ -146,22 +146,43 @@ func (f *BoolField) Transform(fn func(bool) bool) *BoolField {
 }
 
 // Parse parses the field and returns a slice of Error.
-func (f *BoolField) Parse() []Error {
-	var errs []Error
-	f._parse(&errs)
-	return errs
+func (f *BoolField) Parse(opts ...ParseOption) []Error {
+	return f.ParseCtx(context.Background(), opts...)
+}
+
+// ParseCtx parses the field like Parse and passes 'ctx' to the refinements added with RefineCtx.
+// When 'ctx' is done the parse stops and a CodeCanceled or CodeDeadlineExceeded error is reported.
+func (f *BoolField) ParseCtx(ctx context.Context, opts ...ParseOption) []Error {
+	errs := newCollector(opts)
+	f.ParseTo(ctx, &errs)
+	return parseResult(ctx, f.name, &errs, opts)
+}
+
+// ParseValue parses the provided value instead of the bound one and returns a slice of Error.
+func (f *BoolField) ParseValue(value *bool, opts ...ParseOption) []Error {
+	return f.ParseValueCtx(context.Background(), value, opts...)
+}
+
+// ParseValueCtx parses the provided value like ParseValue, with the context handling of ParseCtx.
+func (f *BoolField) ParseValueCtx(ctx context.Context, value *bool, opts ...ParseOption) []Error {
+	errs := newCollector(opts)
+	f.ParseValueTo(ctx, value, &errs)
+	return parseResult(ctx, f.name, &errs, opts)
+}
+
+// Validate parses the field and returns the errors as Errors, or nil if the field is valid.
+func (f *BoolField) Validate(opts ...ParseOption) error {
+	return asError(f.Parse(opts...))
+}
+
+// ValidateValue parses the provided value like ParseValue and returns the errors as Errors, or nil if the value is valid.
+func (f *BoolField) ValidateValue(value *bool, opts ...ParseOption) error {
+	return asError(f.ParseValue(value, opts...))
 }
 
 // Bool takes a pointer to a bool and a variadic argument 'name'.
 // Even if multiple values are passed for 'name', only the first value will be considered.
+// Pass a nil pointer to declare a reusable schema and apply it with ParseValue.
 func Bool(value *bool, name ...string) *BoolField {
-	field := BoolField{
-		value: value,
-	}
-
-	if len(name) > 0 {
-		field.name = name[0]
-	}
-
-	return &field
+	return &BoolField{fieldCore: newFieldCore(value, name)}
 }
@@ -62,3 +62,24 @@ func TestBoolTransform(t *testing.T) {
 		t.Error("input not transformed properly")
 	}
 }
+
+func TestBoolTransformBeforeValidation(t *testing.T) {
+	input := false
+	invert := func(b bool) bool {
+		return !b
+	}
+
+	errs := Bool(&input).Transform(invert).Is(true).Parse()
+	if len(errs) > 0 {
+		t.Error("expected no error")
+	}
+}
+
+func TestBoolOptionalNilPointer(t *testing.T) {
+	var input *bool
+
+	errs := Bool(input).Optional().Is(true).Parse()
+	if len(errs) > 0 {
+		t.Error("expected no error")
+	}
+}
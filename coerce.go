@@ -0,0 +1,109 @@
+package validator
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type integer interface {
+	int8 | int16 | int | int32 | int64 | uint8 | uint16 | uint | uint32 | uint64 | uintptr
+}
+
+type float interface {
+	float32 | float64
+}
+
+// coerce parses the raw string into 'out' when the field is parsed.
+// A nil or blank raw string is treated as a missing value, so Optional and RequiredError work as usual.
+func coerce[T any](raw *string, out *T, parse func(string) (T, error)) func() (*T, error) {
+	return func() (*T, error) {
+		if raw == nil || strings.TrimSpace(*raw) == "" {
+			return nil, nil
+		}
+
+		value, err := parse(strings.TrimSpace(*raw))
+		if err != nil {
+			return nil, err
+		}
+
+		*out = value
+		return out, nil
+	}
+}
+
+func coerceNumberErr(name, raw, kind string, err error) error {
+	if errors.Is(err, strconv.ErrRange) {
+		kind = "range"
+	}
+
+	return ruleMessage(nil, CodeInvalidType+"."+kind, name, map[string]any{"value": raw})
+}
+
+// CoerceInt takes a pointer to a string, parses it as an integer into 'out' and returns a NumberField for 'out'.
+// If the string is not a valid integer for the type of 'out', an error with the CodeInvalidType code is reported.
+// Even if multiple values are passed for 'name', only the first value will be considered.
+func CoerceInt[T integer](raw *string, out *T, name ...string) *NumberField[T] {
+	field := Number(out, name...)
+	field.coerce = coerce(raw, out, func(s string) (T, error) {
+		n, err := parseNumber[T](s)
+		if err != nil {
+			return n, coerceNumberErr(field.name, s, "integer", err)
+		}
+
+		return n, nil
+	})
+
+	return field
+}
+
+// CoerceFloat takes a pointer to a string, parses it as a floating point number into 'out' and returns a NumberField for 'out'.
+// If the string is not a valid number, an error with the CodeInvalidType code is reported.
+// Even if multiple values are passed for 'name', only the first value will be considered.
+func CoerceFloat[T float](raw *string, out *T, name ...string) *NumberField[T] {
+	field := Number(out, name...)
+	field.coerce = coerce(raw, out, func(s string) (T, error) {
+		n, err := parseNumber[T](s)
+		if err != nil {
+			return n, coerceNumberErr(field.name, s, "number", err)
+		}
+
+		return n, nil
+	})
+
+	return field
+}
+
+// CoerceBool takes a pointer to a string, parses it as a boolean into 'out' and returns a BoolField for 'out'.
+// The accepted values are the ones of strconv.ParseBool, such as "true", "false", "1" and "0".
+// Even if multiple values are passed for 'name', only the first value will be considered.
+func CoerceBool(raw *string, out *bool, name ...string) *BoolField {
+	field := Bool(out, name...)
+	field.coerce = coerce(raw, out, func(s string) (bool, error) {
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return b, ruleMessage(nil, CodeInvalidType+".bool", field.name, map[string]any{"value": s})
+		}
+
+		return b, nil
+	})
+
+	return field
+}
+
+// CoerceTime takes a pointer to a string, parses it with the provided layout into 'out' and returns a TimeField for 'out'.
+// Even if multiple values are passed for 'name', only the first value will be considered.
+func CoerceTime(raw *string, out *time.Time, layout string, name ...string) *TimeField {
+	field := Time(out, name...)
+	field.coerce = coerce(raw, out, func(s string) (time.Time, error) {
+		t, err := time.Parse(layout, s)
+		if err != nil {
+			return t, ruleMessage(nil, CodeInvalidType+".time", field.name, map[string]any{"layout": layout, "value": s})
+		}
+
+		return t, nil
+	})
+
+	return field
+}
@@ -0,0 +1,137 @@
+package validator
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCoerceDefault(t *testing.T) {
+	blank := "  "
+	var page int
+
+	if errs := CoerceInt(&blank, &page, "page").Default(10).Min(1).Parse(); len(errs) > 0 || page != 10 {
+		t.Errorf("expected the default in the target, got %d %v", page, errs)
+	}
+
+	var limit int
+	errs := CoerceInt(&blank, &limit, "limit").Default(0).Min(1).Parse()
+	if len(errs) != 1 || errs[0].Code != CodeMin {
+		t.Errorf("expected the rules to run on the default, got %v", errs)
+	}
+}
+
+func TestCoerceInt(t *testing.T) {
+	goodInput := " 42 "
+	badInput := "42abc"
+	overflow := "300"
+	var out int
+	var small uint8
+
+	errs := CoerceInt(&goodInput, &out, "page").Min(1).Max(100).Parse()
+	if len(errs) > 0 {
+		t.Errorf("expected no error, got %v", errs)
+	}
+
+	if out != 42 {
+		t.Errorf("expected 42, got %d", out)
+	}
+
+	errs = CoerceInt(&badInput, &out, "page").Min(1).Parse()
+	if len(errs) != 1 || errs[0].Code != CodeInvalidType || errs[0].Field != "page" {
+		t.Errorf("expected invalid-type error, got %v", errs)
+	}
+
+	errs = CoerceInt(&overflow, &small, "limit").Parse()
+	if len(errs) != 1 || errs[0].Code != CodeInvalidType {
+		t.Errorf("expected invalid-type error, got %v", errs)
+	}
+
+	errs = CoerceInt(&goodInput, &out).Max(10).Parse()
+	if len(errs) != 1 || errs[0].Code != CodeMax {
+		t.Errorf("expected max error, got %v", errs)
+	}
+}
+
+func TestCoerceMissing(t *testing.T) {
+	empty := ""
+	var out int
+
+	errs := CoerceInt(nil, &out, "page").Parse()
+	if len(errs) != 1 || errs[0].Code != CodeRequired {
+		t.Errorf("expected required error, got %v", errs)
+	}
+
+	errs = CoerceInt(&empty, &out, "page").Optional().Min(1).Parse()
+	if len(errs) > 0 {
+		t.Errorf("expected no error, got %v", errs)
+	}
+}
+
+func TestCoerceFloat(t *testing.T) {
+	goodInput := "19.99"
+	badInput := "nineteen"
+	var out float64
+
+	errs := CoerceFloat(&goodInput, &out, "price").Positive().Precision(2).Parse()
+	if len(errs) > 0 || out != 19.99 {
+		t.Errorf("expected no error, got %v", errs)
+	}
+
+	errs = CoerceFloat(&badInput, &out, "price").Parse()
+	if len(errs) != 1 || errs[0].Code != CodeInvalidType {
+		t.Errorf("expected invalid-type error, got %v", errs)
+	}
+}
+
+func TestCoerceBool(t *testing.T) {
+	goodInput := "1"
+	badInput := "yes"
+	var out bool
+
+	errs := CoerceBool(&goodInput, &out, "subscribe").Is(true).Parse()
+	if len(errs) > 0 || !out {
+		t.Errorf("expected no error, got %v", errs)
+	}
+
+	errs = CoerceBool(&badInput, &out, "subscribe").Parse()
+	if len(errs) != 1 || errs[0].Code != CodeInvalidType {
+		t.Errorf("expected invalid-type error, got %v", errs)
+	}
+}
+
+func TestCoerceTime(t *testing.T) {
+	goodInput := "2024-03-15"
+	badInput := "15/03/2024"
+	var out time.Time
+
+	errs := CoerceTime(&goodInput, &out, time.DateOnly, "date").Weekday([]time.Weekday{time.Friday}).Parse()
+	if len(errs) > 0 || out.Day() != 15 {
+		t.Errorf("expected no error, got %v", errs)
+	}
+
+	errs = CoerceTime(&badInput, &out, time.DateOnly, "date").Parse()
+	if len(errs) != 1 || errs[0].Code != CodeInvalidType {
+		t.Errorf("expected invalid-type error, got %v", errs)
+	}
+}
+
+func TestCoerceInStruct(t *testing.T) {
+
+	type Query struct {
+		page, limit string
+	}
+
+	query := Query{page: "2", limit: "500"}
+	var page, limit int
+
+	errs := Struct(&query, "query").
+		Fields(
+			CoerceInt(&query.page, &page, "page").Min(1),
+			CoerceInt(&query.limit, &limit, "limit").Max(100),
+		).
+		Parse()
+
+	if len(errs) != 1 || errs[0].Field != "query.limit" || errs[0].Code != CodeMax {
+		t.Errorf("expected max error for query.limit, got %v", errs)
+	}
+}
@@ -0,0 +1,134 @@
+package validator
+
+import (
+	"context"
+	"reflect"
+)
+
+// presence holds the conditions added with RequiredIf, RequiredUnless, RequiredWith and ExcludedWith.
+// A value is missing if it is nil, the zero value of its type or an empty slice or map.
+type presence struct {
+	conditional bool
+	required    []func() bool
+	excluded    []func() bool
+}
+
+func (p *presence) requireIf(cond func() bool) {
+	p.conditional = true
+	p.required = append(p.required, cond)
+}
+
+func (p *presence) excludeIf(cond func() bool) {
+	p.excluded = append(p.excluded, cond)
+}
+
+// checkPresence reports the value if it is required but missing, or present but excluded.
+// 'done' tells the field to stop and return 'ok', without running its rules.
+func checkPresence[T any](p *presence, name, requiredError string, value *T, errs *Collector) (ok, done bool) {
+	if !p.conditional && len(p.excluded) == 0 {
+		return true, false
+	}
+
+	missing := value == nil || isZero(reflect.ValueOf(value).Elem())
+	if anyHolds(p.excluded) {
+		if missing {
+			return true, true
+		}
+
+		errs.Add(actionError(name, ruleMessage(nil, CodeExcluded, name, nil), CodeExcluded))
+		return false, true
+	}
+
+	if missing && p.conditional {
+		if anyHolds(p.required) {
+			errs.Add(requiredFieldErr(name, requiredError))
+			return false, true
+		}
+
+		return true, true
+	}
+
+	return true, false
+}
+
+func anyHolds(conds []func() bool) bool {
+	for _, cond := range conds {
+		if cond() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isZero(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+
+	return v.IsZero()
+}
+
+// anyPresent tells if any of the values pointed to by 'others' is present.
+// Values that are not pointers are checked as they are.
+func anyPresent(others []any) bool {
+	for _, other := range others {
+		v := reflect.ValueOf(other)
+		if !v.IsValid() {
+			continue
+		}
+
+		if v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				continue
+			}
+			v = v.Elem()
+		}
+
+		if !isZero(v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type conditionalField struct {
+	cond   func() bool
+	fields []Field
+}
+
+func (f conditionalField) ParseTo(ctx context.Context, errs *Collector) bool {
+	if !f.cond() {
+		return true
+	}
+
+	ok := true
+	for _, field := range f.fields {
+		if errs.Done(ctx) {
+			return false
+		}
+
+		if !field.ParseTo(ctx, errs) {
+			ok = false
+		}
+	}
+
+	return ok
+}
+
+func (f conditionalField) exclusive() bool {
+	return true
+}
+
+// When parses the fields only if 'cond' returns true when the parent is parsed.
+// Use it in StructField.Fields. The errors of the fields are reported as if they were passed to Fields directly.
+func When(cond func() bool, fields ...Field) Field {
+	return conditionalField{cond: cond, fields: fields}
+}
+
+// Unless parses the fields only if 'cond' returns false when the parent is parsed, see When.
+func Unless(cond func() bool, fields ...Field) Field {
+	return When(func() bool { return !cond() }, fields...)
+}
@@ -0,0 +1,119 @@
+package validator
+
+import "testing"
+
+func TestRequiredIf(t *testing.T) {
+
+	type Address struct {
+		Country string
+		State   string
+	}
+
+	schema := func(a *Address) []Error {
+		return Struct(a, "address").
+			Fields(
+				String(&a.Country, "country").Length(2),
+				String(&a.State, "state").RequiredIf(func() bool { return a.Country == "US" }).Min(2),
+			).
+			Parse()
+	}
+
+	if errs := schema(&Address{Country: "IN"}); len(errs) > 0 {
+		t.Errorf("expected state to be optional outside the US, got %v", errs)
+	}
+
+	errs := schema(&Address{Country: "US"})
+	if len(errs) != 1 || errs[0].Field != "address.state" || errs[0].Code != CodeRequired {
+		t.Errorf("expected required error for address.state, got %v", errs)
+	}
+
+	errs = schema(&Address{Country: "IN", State: "x"})
+	if len(errs) != 1 || errs[0].Field != "address.state" || errs[0].Code != CodeMin {
+		t.Errorf("expected the rules to run on a present value, got %v", errs)
+	}
+
+	count := 0
+	errs = Number(&count, "count").RequiredUnless(func() bool { return true }).Min(1).Parse()
+	if len(errs) > 0 {
+		t.Errorf("expected zero to count as missing, got %v", errs)
+	}
+}
+
+func TestWhen(t *testing.T) {
+	isBusiness := false
+	vat := "123"
+
+	schema := String(&vat, "vat").
+		When(func() bool { return isBusiness }, func(f *StringField) {
+			f.StartsWith("DE").Length(11)
+		}).
+		Unless(func() bool { return isBusiness }, func(f *StringField) {
+			f.Numeric()
+		})
+
+	if errs := schema.Parse(); len(errs) > 0 {
+		t.Errorf("expected no error, got %v", errs)
+	}
+
+	isBusiness = true
+	errs := schema.Parse()
+	if len(errs) != 2 || errs[0].Code != CodeStartsWith || errs[1].Code != CodeLength || errs[0].Field != "vat" {
+		t.Errorf("expected starts-with and length errors for vat, got %v", errs)
+	}
+}
+
+func TestWhenFields(t *testing.T) {
+
+	type Payment struct {
+		Method string
+		Card   string
+		IBAN   string
+	}
+
+	p := Payment{Method: "card", IBAN: "DE89"}
+	errs := Struct(&p, "payment").
+		Fields(
+			When(func() bool { return p.Method == "card" },
+				String(&p.Card, "card").Length(16),
+			),
+			Unless(func() bool { return p.Method == "card" },
+				String(&p.IBAN, "iban").Min(15),
+			),
+		).
+		Parse()
+
+	if len(errs) != 1 || errs[0].Field != "payment.card" || errs[0].Code != CodeLength {
+		t.Errorf("expected only the card to be checked, got %v", errs)
+	}
+}
+
+func TestRequiredWithExcludedWith(t *testing.T) {
+
+	type Contact struct {
+		Street string
+		Zip    string
+		Email  *string
+		Phone  *string
+	}
+
+	email := "me@mail.com"
+	phone := "12345"
+
+	schema := func(c *Contact) []Error {
+		return Struct(c).
+			Fields(
+				String(&c.Zip, "zip").RequiredWith(&c.Street),
+				String(c.Phone, "phone").Optional().ExcludedWith(c.Email),
+			).
+			Parse()
+	}
+
+	if errs := schema(&Contact{Email: &email}); len(errs) > 0 {
+		t.Errorf("expected no error, got %v", errs)
+	}
+
+	errs := schema(&Contact{Street: "Main St", Email: &email, Phone: &phone})
+	if len(errs) != 2 || errs[0].Field != "zip" || errs[0].Code != CodeRequired || errs[1].Field != "phone" || errs[1].Code != CodeExcluded {
+		t.Errorf("expected required zip and excluded phone, got %v", errs)
+	}
+}
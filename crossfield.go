@@ -0,0 +1,90 @@
+package validator
+
+import (
+	"cmp"
+	"context"
+	"time"
+)
+
+// FieldRef names a field of a struct of type T and gets a pointer to it.
+// It is used by the cross-field rules, such as EqualFields and LessThanField.
+type FieldRef[T, V any] struct {
+	Name string
+	Get  func(*T) *V
+}
+
+// Ref returns a FieldRef for the field named 'name' that is returned by 'get'
+func Ref[T, V any](name string, get func(*T) *V) FieldRef[T, V] {
+	return FieldRef[T, V]{Name: name, Get: get}
+}
+
+// crossFieldRule builds a member that compares 'field' with 'other' and reports 'field' when 'ok' returns false.
+// The rule is skipped when either field is nil. It reads two fields of the struct, so it is exclusive, see StructMember.
+func crossFieldRule[T, V any](field, other FieldRef[T, V], code string, ok func(a, b V) bool, message []string) StructMember[T] {
+	return StructMember[T]{exclusive: true, parse: func(ctx context.Context, value *T, errs *Collector) bool {
+		a, b := field.Get(value), other.Get(value)
+		if a == nil || b == nil || ok(*a, *b) {
+			return true
+		}
+
+		err := ruleMessage(message, code, field.Name, map[string]any{"other": other.Name})
+		errs.Add(actionError(field.Name, err, code))
+		return false
+	}}
+}
+
+// EqualFields checks if 'field' is equal to 'other', for example a password confirmation.
+// The returned StructMember is added to a struct with Members. Failures are reported for 'field' with CodeEqualField.
+func EqualFields[T any, V comparable](field, other FieldRef[T, V], message ...string) StructMember[T] {
+	return crossFieldRule(field, other, CodeEqualField, func(a, b V) bool { return a == b }, message)
+}
+
+// DifferentFrom checks if 'field' is different from 'other'. Failures are reported for 'field' with CodeDifferentField.
+func DifferentFrom[T any, V comparable](field, other FieldRef[T, V], message ...string) StructMember[T] {
+	return crossFieldRule(field, other, CodeDifferentField, func(a, b V) bool { return a != b }, message)
+}
+
+// LessThanField checks if 'field' is less than 'other'. Failures are reported for 'field' with CodeLessThanField.
+// Use BeforeField for times.
+func LessThanField[T any, V cmp.Ordered](field, other FieldRef[T, V], message ...string) StructMember[T] {
+	return crossFieldRule(field, other, CodeLessThanField, func(a, b V) bool { return a < b }, message)
+}
+
+// LessOrEqualField checks if 'field' is less than or equal to 'other', see LessThanField.
+func LessOrEqualField[T any, V cmp.Ordered](field, other FieldRef[T, V], message ...string) StructMember[T] {
+	return crossFieldRule(field, other, CodeLessOrEqualField, func(a, b V) bool { return a <= b }, message)
+}
+
+// GreaterThanField checks if 'field' is greater than 'other', see LessThanField.
+func GreaterThanField[T any, V cmp.Ordered](field, other FieldRef[T, V], message ...string) StructMember[T] {
+	return crossFieldRule(field, other, CodeGreaterThanField, func(a, b V) bool { return a > b }, message)
+}
+
+// GreaterOrEqualField checks if 'field' is greater than or equal to 'other', see LessThanField.
+func GreaterOrEqualField[T any, V cmp.Ordered](field, other FieldRef[T, V], message ...string) StructMember[T] {
+	return crossFieldRule(field, other, CodeGreaterOrEqualField, func(a, b V) bool { return a >= b }, message)
+}
+
+// BeforeField checks if the time of 'field' is before the time of 'other'.
+// Failures are reported for 'field' with CodeLessThanField, like LessThanField.
+func BeforeField[T any](field, other FieldRef[T, time.Time], message ...string) StructMember[T] {
+	return crossFieldRule(field, other, CodeLessThanField, func(a, b time.Time) bool { return a.Compare(b) < 0 }, message)
+}
+
+// BeforeOrEqualField checks if the time of 'field' is before or equal to the time of 'other', see BeforeField.
+// Failures are reported with CodeLessOrEqualField.
+func BeforeOrEqualField[T any](field, other FieldRef[T, time.Time], message ...string) StructMember[T] {
+	return crossFieldRule(field, other, CodeLessOrEqualField, func(a, b time.Time) bool { return a.Compare(b) <= 0 }, message)
+}
+
+// AfterField checks if the time of 'field' is after the time of 'other', see BeforeField.
+// Failures are reported with CodeGreaterThanField.
+func AfterField[T any](field, other FieldRef[T, time.Time], message ...string) StructMember[T] {
+	return crossFieldRule(field, other, CodeGreaterThanField, func(a, b time.Time) bool { return a.Compare(b) > 0 }, message)
+}
+
+// AfterOrEqualField checks if the time of 'field' is after or equal to the time of 'other', see BeforeField.
+// Failures are reported with CodeGreaterOrEqualField.
+func AfterOrEqualField[T any](field, other FieldRef[T, time.Time], message ...string) StructMember[T] {
+	return crossFieldRule(field, other, CodeGreaterOrEqualField, func(a, b time.Time) bool { return a.Compare(b) >= 0 }, message)
+}
@@ -0,0 +1,142 @@
+package validator
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEqualFields(t *testing.T) {
+
+	type Signup struct {
+		Password        string
+		ConfirmPassword string
+		OldPassword     string
+	}
+
+	password := Ref("password", func(s *Signup) *string { return &s.Password })
+	confirm := Ref("confirm_password", func(s *Signup) *string { return &s.ConfirmPassword })
+	old := Ref("old_password", func(s *Signup) *string { return &s.OldPassword })
+
+	rules := []StructMember[Signup]{
+		EqualFields(confirm, password),
+		DifferentFrom(password, old, "pick a new password"),
+	}
+	schema := Struct[Signup](nil, "signup").Members(rules...)
+
+	if errs := schema.ParseValue(&Signup{Password: "secret1", ConfirmPassword: "secret1", OldPassword: "secret"}); len(errs) > 0 {
+		t.Errorf("expected no error, got %v", errs)
+	}
+
+	errs := schema.ParseValue(&Signup{Password: "secret", ConfirmPassword: "secret1", OldPassword: "secret"})
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %v", errs)
+	}
+
+	if errs[0].Field != "signup.confirm_password" || errs[0].Code != CodeEqualField || errs[0].Message != "confirm_password must be equal to password" {
+		t.Errorf("unexpected error %+v", errs[0])
+	}
+
+	if errs[1].Field != "signup.password" || errs[1].Code != CodeDifferentField || errs[1].Message != "pick a new password" {
+		t.Errorf("unexpected error %+v", errs[1])
+	}
+
+	if errs[0].Params["other"] != "password" {
+		t.Errorf("expected the other field in the params, got %v", errs[0].Params)
+	}
+}
+
+func TestOrderedFields(t *testing.T) {
+
+	type Booking struct {
+		Start    time.Time
+		End      time.Time
+		MinPrice float64
+		MaxPrice float64
+		Nights   *int
+		Guests   *int
+	}
+
+	schema := Struct[Booking](nil).
+		Members(
+			BeforeField(
+				Ref("start", func(b *Booking) *time.Time { return &b.Start }),
+				Ref("end", func(b *Booking) *time.Time { return &b.End }),
+			),
+			GreaterOrEqualField(
+				Ref("max_price", func(b *Booking) *float64 { return &b.MaxPrice }),
+				Ref("min_price", func(b *Booking) *float64 { return &b.MinPrice }),
+			),
+			LessOrEqualField(
+				Ref("guests", func(b *Booking) *int { return b.Guests }),
+				Ref("nights", func(b *Booking) *int { return b.Nights }),
+			),
+		)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	good := Booking{Start: start, End: start.Add(24 * time.Hour), MinPrice: 10, MaxPrice: 10}
+	if errs := schema.ParseValue(&good); len(errs) > 0 {
+		t.Errorf("expected no error, got %v", errs)
+	}
+
+	bad := Booking{Start: start, End: start, MinPrice: 20, MaxPrice: 10}
+	errs := schema.ParseValue(&bad)
+	if len(errs) != 2 || errs[0].Field != "start" || errs[0].Code != CodeLessThanField || errs[1].Field != "max_price" || errs[1].Code != CodeGreaterOrEqualField {
+		t.Errorf("expected errors for start and max_price, got %v", errs)
+	}
+}
+
+func TestTimeFields(t *testing.T) {
+
+	type Rental struct {
+		PickUp  time.Time
+		DropOff time.Time
+		Grace   time.Duration
+		Late    time.Duration
+	}
+
+	schema := Struct[Rental](nil).
+		Members(
+			AfterField(
+				Ref("drop_off", func(r *Rental) *time.Time { return &r.DropOff }),
+				Ref("pick_up", func(r *Rental) *time.Time { return &r.PickUp }),
+			),
+			LessOrEqualField(
+				Ref("late", func(r *Rental) *time.Duration { return &r.Late }),
+				Ref("grace", func(r *Rental) *time.Duration { return &r.Grace }),
+			),
+		)
+
+	pickUp := time.Date(1500, 1, 1, 0, 0, 0, 0, time.UTC)
+	if errs := schema.ParseValue(&Rental{PickUp: pickUp, DropOff: pickUp.AddDate(1000, 0, 0), Grace: time.Hour}); len(errs) > 0 {
+		t.Errorf("expected no error, got %v", errs)
+	}
+
+	errs := schema.ParseValue(&Rental{PickUp: pickUp, DropOff: pickUp, Late: time.Hour})
+	if len(errs) != 2 || errs[0].Field != "drop_off" || errs[0].Code != CodeGreaterThanField || errs[1].Field != "late" {
+		t.Errorf("expected errors for drop_off and late, got %v", errs)
+	}
+}
+
+func TestCrossFieldConcurrent(t *testing.T) {
+
+	type Signup struct {
+		Password        string
+		ConfirmPassword string
+	}
+
+	input := Signup{Password: " secret ", ConfirmPassword: "secret"}
+	errs := Struct(&input).Concurrent(4).
+		Fields(
+			String(&input.Password, "password").TrimSpace(),
+			String(&input.ConfirmPassword, "confirm_password").TrimSpace(),
+		).
+		Members(EqualFields(
+			Ref("confirm_password", func(s *Signup) *string { return &s.ConfirmPassword }),
+			Ref("password", func(s *Signup) *string { return &s.Password }),
+		)).
+		Parse()
+
+	if len(errs) > 0 {
+		t.Errorf("expected the rule to see the trimmed fields, got %v", errs)
+	}
+}
@@ -0,0 +1,94 @@
+package validator
+
+import (
+	"errors"
+	"strings"
+)
+
+// Error implements the error interface
+func (e Error) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+
+	return e.Field + ": " + e.Message
+}
+
+// Is reports whether the target is an Error with the same Code.
+// It lets errors.Is match on codes, for example errors.Is(err, Error{Code: CodeMin}).
+func (e Error) Is(target error) bool {
+	switch t := target.(type) {
+	case Error:
+		return t.Code == e.Code
+	case *Error:
+		return t != nil && t.Code == e.Code
+	}
+
+	return false
+}
+
+// Errors is a list of Error that implements the error interface.
+// It unwraps to the individual errors, so errors.Is and errors.As can be used to inspect them.
+type Errors []Error
+
+// Error joins the messages of all the errors
+func (e Errors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the individual errors
+func (e Errors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, err := range e {
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
+// Truncated tells if the parse stopped at the limit of WithMaxErrors or WithFailFast, so that there may be more errors.
+func (e Errors) Truncated() bool {
+	return len(e) > 0 && e[len(e)-1].truncated
+}
+
+// asError returns nil when there are no errors, so that the result can be compared with nil.
+func asError(errs []Error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return Errors(errs)
+}
+
+type paramsError struct {
+	err    error
+	params map[string]any
+}
+
+func (e paramsError) Error() string {
+	return e.err.Error()
+}
+
+func (e paramsError) Unwrap() error {
+	return e.err
+}
+
+// WithParams attaches params to an error returned by a refinement. They are reported in Error.Params.
+func WithParams(err error, params map[string]any) error {
+	return paramsError{err: err, params: params}
+}
+
+// errorParams returns the params attached to 'err' with WithParams
+func errorParams(err error) map[string]any {
+	var pe paramsError
+	if errors.As(err, &pe) {
+		return pe.params
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	goodInput := "aaditya"
+	badInput := "aadi"
+
+	if err := String(&goodInput, "name").Min(5).Validate(); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	err := String(&badInput, "name").Min(5).Alpha().Email().Validate()
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	var errs Errors
+	if !errors.As(err, &errs) || len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %v", err)
+	}
+
+	if !errors.Is(err, Error{Code: CodeMin}) || !errors.Is(err, &Error{Code: CodeEmail}) {
+		t.Error("expected error to match min and email codes")
+	}
+
+	if errors.Is(err, Error{Code: CodeAlpha}) {
+		t.Error("expected error not to match alpha code")
+	}
+
+	var first Error
+	if !errors.As(err, &first) || first.Code != CodeMin || first.Field != "name" {
+		t.Errorf("expected first error to be a min error, got %v", first)
+	}
+
+	if err.Error() != "name: name should have atleast 5 characters; name: name is not a valid email" {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+}
+
+func TestValidateValue(t *testing.T) {
+	schema := Number[int](nil, "age").Min(18)
+	goodInput := 21
+	badInput := 17
+
+	if err := schema.ValidateValue(&goodInput); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	if err := schema.ValidateValue(&badInput); !errors.Is(err, Error{Code: CodeMin}) {
+		t.Errorf("expected min error, got %v", err)
+	}
+}
+
+func TestErrorParams(t *testing.T) {
+	password := "secret"
+	age := 15
+	tags := []string{"a", "b", "c"}
+	labels := map[string]string{}
+	admin := true
+
+	cases := []struct {
+		errs   []Error
+		params map[string]any
+	}{
+		{String(&password).Min(8).Parse(), map[string]any{"min": 8, "actual": 6}},
+		{String(&password).Min(8, "password is too short").Parse(), map[string]any{"min": 8, "actual": 6}},
+		{String(&password).IsOneOf([]string{"a", "b"}).Parse(), map[string]any{"allowed": []string{"a", "b"}}},
+		{Number(&age).Between(18, 60, Inclusive).Parse(), map[string]any{"min": 18, "max": 60, "bounds": Inclusive, "actual": 15}},
+		{Slice(&tags).Max(2).Parse(), map[string]any{"max": 2, "actual": 3}},
+		{Map(&labels).Min(1).Parse(), map[string]any{"min": 1, "actual": 0}},
+		{Bool(&admin).Is(false).Parse(), map[string]any{"expected": false}},
+		{String(&password).Email().Parse(), nil},
+	}
+
+	for i, c := range cases {
+		if len(c.errs) != 1 {
+			t.Errorf("case %d: expected 1 error, got %v", i, c.errs)
+			continue
+		}
+
+		if !reflect.DeepEqual(c.errs[0].Params, c.params) {
+			t.Errorf("case %d: expected params %v, got %v", i, c.params, c.errs[0].Params)
+		}
+	}
+}
+
+func TestWithParams(t *testing.T) {
+	username := "admin"
+	reserved := []string{"admin", "root"}
+
+	errs := String(&username, "username").
+		Refine(func(s string) error {
+			if slices.Contains(reserved, s) {
+				return WithParams(errors.New("username is reserved"), map[string]any{"reserved": reserved})
+			}
+
+			return nil
+		}).
+		Parse()
+
+	if len(errs) != 1 || errs[0].Message != "username is reserved" {
+		t.Fatalf("expected refinement error, got %v", errs)
+	}
+
+	if !reflect.DeepEqual(errs[0].Params, map[string]any{"reserved": reserved}) {
+		t.Errorf("expected params to be attached, got %v", errs[0].Params)
+	}
+}
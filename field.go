@@ -0,0 +1,263 @@
+package validator
+
+import (
+	"context"
+	"reflect"
+)
+
+// fieldAction is a single step of the pipeline of a field, only one of its functions is set.
+type fieldAction[T any] struct {
+	validator      func(T) error
+	refinement     func(context.Context, T) error
+	transformer    func(T) T
+	nested         func(context.Context, *T, *Collector) bool
+	code           string
+	refinementData RefinementData
+	// exclusive marks a nested action that can read or write all of the value, so that it never runs concurrently
+	exclusive bool
+}
+
+// fieldCore is embedded by every field type. It holds the bound value, the presence rules
+// and the pipeline of actions, and runs them when the field is parsed.
+type fieldCore[T any] struct {
+	value         *T
+	name          string
+	optional      bool
+	requiredError string
+	actions       []fieldAction[T]
+	abortEarly    bool
+	presence      presence
+	// coerce is set by the Coerce functions and produces the value to parse instead of 'value'
+	coerce func() (*T, error)
+	// scoped makes the field of the refinement data a field inside this one, like the fields of a struct
+	scoped bool
+	// patch is set by FromPatch and tells an absent value from a null one
+	patch          *Patch[T]
+	nullable       bool
+	emptyAsMissing bool
+	defaultValue   func() T
+	// dependent is set by When, whose condition usually reads other fields
+	dependent bool
+	// clone copies the elements of slices and maps, which the value only refers to, for snapshot
+	clone func(T) T
+}
+
+// newFieldCore binds the core to 'value'. Only the first of the names is used.
+func newFieldCore[T any](value *T, name []string) fieldCore[T] {
+	c := fieldCore[T]{value: value}
+	if len(name) > 0 {
+		c.name = name[0]
+	}
+
+	return c
+}
+
+func (c *fieldCore[T]) addValidation(fn func(T) error, code string) {
+	c.actions = append(c.actions, fieldAction[T]{validator: fn, code: code})
+}
+
+func (c *fieldCore[T]) addRefinement(fn func(context.Context, T) error, refinementData RefinementData) {
+	c.actions = append(c.actions, fieldAction[T]{refinement: fn, refinementData: refinementData})
+}
+
+func (c *fieldCore[T]) addTransformer(fn func(T) T) {
+	c.actions = append(c.actions, fieldAction[T]{transformer: fn})
+}
+
+func (c *fieldCore[T]) addNested(fn func(context.Context, *T, *Collector) bool) {
+	c.actions = append(c.actions, fieldAction[T]{nested: fn})
+}
+
+func (c *fieldCore[T]) bindPatch(p *Patch[T]) {
+	c.value = &p.Value
+	c.patch = p
+}
+
+// exclusive tells if the conditions of the field read other fields, which must not be parsed at the same time, see fieldAction.
+func (c *fieldCore[T]) exclusive() bool {
+	return c.dependent || c.presence.conditional || len(c.presence.excluded) > 0
+}
+
+// snapshot saves the bound value and returns a function that restores it.
+// OneOf uses it to undo the transformations of the fields that didn't match.
+func (c *fieldCore[T]) snapshot() (restore func()) {
+	if c.patch != nil {
+		saved := *c.patch
+		if c.clone != nil {
+			saved.Value = c.clone(saved.Value)
+		}
+		return func() { *c.patch = saved }
+	}
+
+	if c.value == nil {
+		return func() {}
+	}
+
+	saved := *c.value
+	if c.clone != nil {
+		saved = c.clone(saved)
+	}
+	return func() { *c.value = saved }
+}
+
+// boundValue returns the value the field is bound to, coerced if the field has a coerce function.
+// 'done' tells the field to stop and return 'ok', because the bound Patch is null or absent.
+func (c *fieldCore[T]) boundValue(errs *Collector) (value *T, ok, done bool) {
+	if c.patch != nil {
+		return c.patchValue(errs)
+	}
+
+	if c.coerce == nil {
+		return c.value, true, false
+	}
+
+	value, err := c.coerce()
+	if err != nil {
+		errs.Add(actionError(c.name, err, CodeInvalidType))
+		return nil, false, true
+	}
+
+	// a blank string is missing, and the target of the coerce function holds the default
+	if value == nil && c.defaultValue != nil && c.value != nil {
+		if ok, done := checkPresence(&c.presence, c.name, c.requiredError, (*T)(nil), errs); done {
+			return nil, ok, true
+		}
+
+		*c.value = c.defaultValue()
+		return c.value, true, false
+	}
+
+	return value, true, false
+}
+
+// patchValue applies the states of the bound Patch. A null value is accepted by Nullable fields,
+// and missing otherwise, just like an absent value.
+func (c *fieldCore[T]) patchValue(errs *Collector) (value *T, ok, done bool) {
+	p := c.patch
+	if p.Set && !p.Null {
+		return &p.Value, true, false
+	}
+
+	if p.Null && c.nullable {
+		return nil, true, true
+	}
+
+	if ok, done := checkPresence(&c.presence, c.name, c.requiredError, (*T)(nil), errs); done {
+		return nil, ok, true
+	}
+
+	if c.defaultValue != nil {
+		p.Value, p.Set, p.Null = c.defaultValue(), true, false
+		return &p.Value, true, false
+	}
+
+	ok, done = c.missing(errs)
+	return nil, ok, done
+}
+
+// checkValue reports missing, required and excluded values and fills in the default of missing values.
+// 'done' tells the field to stop and return 'ok', without running its actions.
+func (c *fieldCore[T]) checkValue(value *T, errs *Collector) (ok, done bool) {
+	if ok, done := checkPresence(&c.presence, c.name, c.requiredError, value, errs); done {
+		return ok, true
+	}
+
+	if value == nil {
+		// a nil pointer is null for nullable fields
+		if c.nullable {
+			return true, true
+		}
+
+		return c.missing(errs)
+	}
+
+	if c.emptyAsMissing && isZero(reflect.ValueOf(value).Elem()) {
+		if c.defaultValue != nil {
+			*value = c.defaultValue()
+			return true, false
+		}
+
+		return c.missing(errs)
+	}
+
+	return true, false
+}
+
+// missing reports a missing value unless the field is optional.
+func (c *fieldCore[T]) missing(errs *Collector) (ok, done bool) {
+	if !c.optional {
+		errs.Add(requiredFieldErr(c.name, c.requiredError))
+		return false, true
+	}
+
+	return true, true
+}
+
+// parse checks the value and runs the actions on it in order.
+func (c *fieldCore[T]) parse(ctx context.Context, value *T, errs *Collector) bool {
+	if ok, done := c.checkValue(value, errs); done {
+		return ok
+	}
+
+	isFieldParsedSuccessfully := true
+	for _, action := range c.actions {
+		if errs.Done(ctx) {
+			return false
+		}
+
+		if !c.parseAction(ctx, value, action, errs) {
+			isFieldParsedSuccessfully = false
+			if c.abortEarly {
+				return false
+			}
+		}
+	}
+
+	return isFieldParsedSuccessfully
+}
+
+// parseAction runs a single action and returns false if it failed.
+// A refinement that fails after 'ctx' is done fails without an error, since the parse reports the context instead.
+func (c *fieldCore[T]) parseAction(ctx context.Context, value *T, action fieldAction[T], errs *Collector) bool {
+	if action.validator != nil {
+		if err := action.validator(*value); err != nil {
+			errs.Add(actionError(c.name, err, action.code))
+			return false
+		}
+	} else if action.refinement != nil {
+		if err := action.refinement(ctx, *value); err != nil {
+			if ctx.Err() != nil {
+				return false
+			}
+
+			errs.Add(c.refinementError(err, action.refinementData))
+			return false
+		}
+	} else if action.nested != nil {
+		return action.nested(ctx, value, errs)
+	} else if action.transformer != nil {
+		*value = action.transformer(*value)
+	}
+
+	return true
+}
+
+// refinementError reports a failed refinement for the field, or for the field named in the refinement data.
+func (c *fieldCore[T]) refinementError(err error, refinementData RefinementData) Error {
+	name := c.name
+	if refinementData.Field != "" && !c.scoped {
+		name = refinementData.Field
+	}
+
+	me := newError(name, err.Error(), CodeRefinement)
+	me.Params = errorParams(err)
+	if refinementData.Field != "" && c.scoped {
+		me.Path = append(fieldPath(c.name), fieldPath(refinementData.Field)...)
+		me.Field = formatPath(me.Path)
+	}
+	if refinementData.Code != "" {
+		me.Code = refinementData.Code
+	}
+
+	return me
+}
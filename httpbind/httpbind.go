@@ -0,0 +1,249 @@
+// Package httpbind decodes net/http requests into structs and validates them with the validator package.
+//
+// JSON bodies are decoded with encoding/json. Form values, query parameters and path values are
+// assigned to the struct fields tagged with `form:"name"`, `query:"name"` and `path:"name"`.
+// Requests that can't be decoded are answered with 400 Bad Request and requests that fail
+// validation with 422 Unprocessable Entity.
+package httpbind
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/aaditya-23/validator"
+)
+
+// CodeInvalidBody is the code of the error reported when the body of a request can't be decoded.
+const CodeInvalidBody = "invalid-body"
+
+const defaultMaxBodyBytes = 1 << 20
+
+// Schema is implemented by the field types of the validator package that are declared as reusable schemas,
+// for example *validator.StructField[T].
+type Schema[T any] interface {
+	ParseValueCtx(ctx context.Context, value *T, opts ...validator.ParseOption) []validator.Error
+}
+
+// Renderer writes the response for a request that failed to bind.
+type Renderer func(w http.ResponseWriter, r *http.Request, status int, errs []validator.Error)
+
+// Binder decodes and validates requests. The zero value is ready to use.
+type Binder struct {
+	// MaxBodyBytes limits the size of request bodies. It defaults to 1 MB.
+	MaxBodyBytes int64
+	// Renderer writes the error responses. It defaults to RenderJSON.
+	Renderer Renderer
+}
+
+var defaultBinder = &Binder{}
+
+// Error is returned when a request fails to bind.
+// Status is http.StatusBadRequest when the request can't be decoded and
+// http.StatusUnprocessableEntity when it fails validation.
+type Error struct {
+	Status int
+	Errors []validator.Error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("httpbind: %s: %s", http.StatusText(e.Status), validator.Errors(e.Errors).Error())
+}
+
+// Unwrap returns the validation errors as validator.Errors
+func (e *Error) Unwrap() error {
+	return validator.Errors(e.Errors)
+}
+
+// Decode decodes the body, the query parameters and the path values of the request into 'dst',
+// which must be a pointer to a struct. Values decoded later override earlier ones, in that order.
+func (b *Binder) Decode(r *http.Request, dst any) error {
+	if err := b.decodeBody(r, dst); err != nil {
+		return err
+	}
+
+	return decodeValues(r, dst)
+}
+
+// Bind decodes the request into 'dst' and validates it with the 'validate' struct tags, see validator.ValidateStruct.
+// Validation stops when the context of the request is done.
+func (b *Binder) Bind(r *http.Request, dst any) error {
+	if err := b.Decode(r, dst); err != nil {
+		return err
+	}
+
+	errs, err := validator.ValidateStructCtx(r.Context(), dst)
+	if err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return &Error{Status: http.StatusUnprocessableEntity, Errors: errs}
+	}
+
+	return nil
+}
+
+// Render writes the response for an error returned by Decode or Bind.
+// Errors that are not an *Error are answered with 500 Internal Server Error.
+func (b *Binder) Render(w http.ResponseWriter, r *http.Request, err error) {
+	renderer := b.Renderer
+	if renderer == nil {
+		renderer = RenderJSON
+	}
+
+	var bindErr *Error
+	if !errors.As(err, &bindErr) {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	renderer(w, r, bindErr.Status, bindErr.Errors)
+}
+
+// Bind binds the request with the default Binder
+func Bind(r *http.Request, dst any) error {
+	return defaultBinder.Bind(r, dst)
+}
+
+// BindSchema decodes the request into 'dst' and validates it with 'schema' instead of the struct tags.
+// A nil Binder uses the defaults.
+func BindSchema[T any](b *Binder, r *http.Request, dst *T, schema Schema[T]) error {
+	if b == nil {
+		b = defaultBinder
+	}
+
+	if err := b.Decode(r, dst); err != nil {
+		return err
+	}
+
+	if errs := schema.ParseValueCtx(r.Context(), dst); len(errs) > 0 {
+		return &Error{Status: http.StatusUnprocessableEntity, Errors: errs}
+	}
+
+	return nil
+}
+
+type contextKey[T any] struct{}
+
+// Middleware binds every request into a new T before calling the next handler, which can get it with Value.
+// The request is validated with 'schema', or with the struct tags of T if 'schema' is nil.
+// Requests that fail to bind are answered by the Binder and never reach the next handler. A nil Binder uses the defaults.
+func Middleware[T any](b *Binder, schema Schema[T]) func(http.Handler) http.Handler {
+	if b == nil {
+		b = defaultBinder
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			dst := new(T)
+
+			var err error
+			if schema != nil {
+				err = BindSchema(b, r, dst, schema)
+			} else {
+				err = b.Bind(r, dst)
+			}
+
+			if err != nil {
+				b.Render(w, r, err)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), contextKey[T]{}, dst)))
+		})
+	}
+}
+
+// Value returns the value bound by Middleware, or nil if the request didn't go through a Middleware for T.
+func Value[T any](r *http.Request) *T {
+	v, _ := r.Context().Value(contextKey[T]{}).(*T)
+	return v
+}
+
+type jsonError struct {
+	Field   string         `json:"field"`
+	Message string         `json:"message"`
+	Code    string         `json:"code"`
+	Params  map[string]any `json:"params,omitempty"`
+}
+
+// RenderJSON writes the errors as {"errors": [{"field": ..., "message": ..., "code": ..., "params": ...}]}.
+// The params are left out for rules without params.
+func RenderJSON(w http.ResponseWriter, r *http.Request, status int, errs []validator.Error) {
+	body := struct {
+		Errors []jsonError `json:"errors"`
+	}{Errors: make([]jsonError, 0, len(errs))}
+
+	for _, e := range errs {
+		body.Errors = append(body.Errors, jsonError{Field: e.Field, Message: e.Message, Code: e.Code, Params: e.Params})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func (b *Binder) decodeBody(r *http.Request, dst any) error {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil
+	}
+
+	limit := b.MaxBodyBytes
+	if limit <= 0 {
+		limit = defaultMaxBodyBytes
+	}
+	r.Body = http.MaxBytesReader(nil, r.Body, limit)
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		return decodeJSON(r, dst)
+	case mediaType == "application/x-www-form-urlencoded":
+		if err := r.ParseForm(); err != nil {
+			return bodyError(err)
+		}
+	case mediaType == "multipart/form-data":
+		if err := r.ParseMultipartForm(limit); err != nil {
+			return bodyError(err)
+		}
+	}
+
+	return nil
+}
+
+func decodeJSON(r *http.Request, dst any) error {
+	err := json.NewDecoder(r.Body).Decode(dst)
+	if err == nil {
+		return nil
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		e := validator.Error{
+			Field:   typeErr.Field,
+			Message: fmt.Sprintf("%s must be of type %s", typeErr.Field, typeErr.Type),
+			Code:    validator.CodeInvalidType,
+		}
+		for _, name := range strings.Split(typeErr.Field, ".") {
+			e.Path = append(e.Path, validator.PathSegment{Kind: validator.SegmentField, Name: name})
+		}
+
+		return &Error{Status: http.StatusBadRequest, Errors: []validator.Error{e}}
+	}
+
+	return bodyError(err)
+}
+
+func bodyError(err error) error {
+	var maxErr *http.MaxBytesError
+	message := "request body is not valid: " + err.Error()
+	if errors.As(err, &maxErr) {
+		message = fmt.Sprintf("request body must not be larger than %d bytes", maxErr.Limit)
+	}
+
+	return &Error{Status: http.StatusBadRequest, Errors: []validator.Error{{Message: message, Code: CodeInvalidBody}}}
+}
@@ -0,0 +1,199 @@
+package httpbind
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/aaditya-23/validator"
+)
+
+type createUser struct {
+	ID      int      `path:"id"`
+	Name    string   `json:"name" validate:"required,min=3"`
+	Email   string   `json:"email" validate:"required,email"`
+	Notify  bool     `query:"notify"`
+	Tags    []string `json:"tags" query:"tag" validate:"max=2"`
+	Referer *string  `query:"ref"`
+}
+
+type errorBody struct {
+	Errors []struct {
+		Field   string `json:"field"`
+		Message string `json:"message"`
+		Code    string `json:"code"`
+	} `json:"errors"`
+}
+
+func newJSONRequest(target, body string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	return r
+}
+
+func TestBind(t *testing.T) {
+	var user createUser
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if err := Bind(r, &user); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+
+	r := newJSONRequest("/users/42?notify=true&tag=a&tag=b&ref=home", `{"name": "aaditya", "email": "me@mail.com"}`)
+	mux.ServeHTTP(httptest.NewRecorder(), r)
+
+	if user.ID != 42 || user.Name != "aaditya" || !user.Notify || len(user.Tags) != 2 || user.Referer == nil || *user.Referer != "home" {
+		t.Errorf("request not decoded properly: %+v", user)
+	}
+}
+
+func TestBindForm(t *testing.T) {
+
+	type login struct {
+		Username string `form:"username" validate:"required,alphanum"`
+		Remember bool   `form:"remember"`
+	}
+
+	form := url.Values{"username": {"aaditya23"}, "remember": {"on"}}
+	r := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var dst login
+	err := Bind(r, &dst)
+
+	var bindErr *Error
+	if !errors.As(err, &bindErr) || bindErr.Status != http.StatusBadRequest || bindErr.Errors[0].Field != "remember" {
+		t.Fatalf("expected bad request for remember, got %v", err)
+	}
+
+	form.Set("remember", "true")
+	r = httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	if err := Bind(r, &dst); err != nil || dst.Username != "aaditya23" || !dst.Remember {
+		t.Errorf("expected no error, got %v %+v", err, dst)
+	}
+}
+
+func TestBindErrors(t *testing.T) {
+	cases := []struct {
+		name   string
+		target string
+		body   string
+		status int
+		fields []string
+	}{
+		{"malformed json", "/users/1", `{"name":`, http.StatusBadRequest, []string{""}},
+		{"wrong json type", "/users/1", `{"name": 42}`, http.StatusBadRequest, []string{"name"}},
+		{"wrong path type", "/users/abc", `{}`, http.StatusBadRequest, []string{"id"}},
+		{"invalid fields", "/users/1?tag=a&tag=b&tag=c", `{"name": "aa", "email": "aaditya"}`, http.StatusUnprocessableEntity, []string{"name", "email", "tags"}},
+	}
+
+	for _, c := range cases {
+		mux := http.NewServeMux()
+		mux.HandleFunc("POST /users/{id}", func(w http.ResponseWriter, r *http.Request) {
+			var user createUser
+			if err := Bind(r, &user); err != nil {
+				defaultBinder.Render(w, r, err)
+			}
+		})
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, newJSONRequest(c.target, c.body))
+
+		if w.Code != c.status {
+			t.Errorf("%s: expected status %d, got %d", c.name, c.status, w.Code)
+			continue
+		}
+
+		if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("%s: unexpected content type %q", c.name, ct)
+		}
+
+		var body errorBody
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+
+		if len(body.Errors) != len(c.fields) {
+			t.Errorf("%s: expected %d errors, got %+v", c.name, len(c.fields), body.Errors)
+			continue
+		}
+
+		for i, field := range c.fields {
+			if body.Errors[i].Field != field {
+				t.Errorf("%s: expected error for %q, got %q", c.name, field, body.Errors[i].Field)
+			}
+		}
+	}
+}
+
+func TestBindBodyLimit(t *testing.T) {
+	b := &Binder{MaxBodyBytes: 8}
+
+	var user createUser
+	err := b.Bind(newJSONRequest("/users", `{"name": "aaditya"}`), &user)
+
+	var bindErr *Error
+	if !errors.As(err, &bindErr) || bindErr.Status != http.StatusBadRequest || bindErr.Errors[0].Code != CodeInvalidBody {
+		t.Errorf("expected invalid body error, got %v", err)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	var got *createUser
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = Value[createUser](r)
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	handler := Middleware[createUser](nil, nil)(next)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newJSONRequest("/users", `{"name": "aaditya", "email": "me@mail.com"}`))
+	if w.Code != http.StatusCreated || got == nil || got.Name != "aaditya" {
+		t.Errorf("expected request to reach the handler, got %d %+v", w.Code, got)
+	}
+
+	got = nil
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, newJSONRequest("/users", `{"name": "aaditya"}`))
+	if w.Code != http.StatusUnprocessableEntity || got != nil {
+		t.Errorf("expected request to be rejected, got %d", w.Code)
+	}
+}
+
+func TestMiddlewareSchema(t *testing.T) {
+
+	type search struct {
+		Query string `query:"q"`
+		Page  int    `query:"page"`
+	}
+
+	schema := validator.Struct[search](nil).
+		Members(
+			validator.Member(func(s *search) *string { return &s.Query }, validator.String(nil, "q").Min(2)),
+			validator.Member(func(s *search) *int { return &s.Page }, validator.Number[int](nil, "page").Min(1)),
+		)
+
+	var status int
+	var errs []validator.Error
+	b := &Binder{Renderer: func(w http.ResponseWriter, r *http.Request, s int, e []validator.Error) {
+		status, errs = s, e
+		w.WriteHeader(http.StatusTeapot)
+	}}
+
+	handler := Middleware[search](b, schema)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/search?q=a&page=0", nil))
+
+	if w.Code != http.StatusTeapot || status != http.StatusUnprocessableEntity || len(errs) != 2 {
+		t.Errorf("expected custom renderer to receive 2 errors, got %d %d %v", w.Code, status, errs)
+	}
+}
@@ -0,0 +1,137 @@
+package httpbind
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aaditya-23/validator"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// decodeValues assigns form values, query parameters and path values to the fields tagged with
+// 'form', 'query' and 'path'. Every value that can't be converted is reported.
+func decodeValues(r *http.Request, dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("httpbind: expected a non-nil pointer to a struct, got %T", dst)
+	}
+	rv = rv.Elem()
+
+	query := r.URL.Query()
+	sources := []struct {
+		tag    string
+		lookup func(name string) []string
+	}{
+		{"form", func(name string) []string { return r.PostForm[name] }},
+		{"query", func(name string) []string { return query[name] }},
+		{"path", func(name string) []string {
+			if v := r.PathValue(name); v != "" {
+				return []string{v}
+			}
+			return nil
+		}},
+	}
+
+	var errs []validator.Error
+	t := rv.Type()
+	for _, source := range sources {
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			name, _, _ := strings.Cut(sf.Tag.Get(source.tag), ",")
+			if name == "" || name == "-" || !sf.IsExported() {
+				continue
+			}
+
+			values := source.lookup(name)
+			if len(values) == 0 {
+				continue
+			}
+
+			if err := setValue(rv.Field(i), values); err != nil {
+				errs = append(errs, validator.Error{
+					Field:   name,
+					Message: fmt.Sprintf("%s %s", name, err),
+					Code:    validator.CodeInvalidType,
+					Path:    []validator.PathSegment{{Kind: validator.SegmentField, Name: name}},
+				})
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return &Error{Status: http.StatusBadRequest, Errors: errs}
+	}
+
+	return nil
+}
+
+// setValue converts the values to the type of the field. Slices take every value, other types the first one.
+func setValue(field reflect.Value, values []string) error {
+	switch field.Kind() {
+	case reflect.Pointer:
+		elem := reflect.New(field.Type().Elem())
+		if err := setValue(elem.Elem(), values); err != nil {
+			return err
+		}
+		field.Set(elem)
+		return nil
+	case reflect.Slice:
+		slice := reflect.MakeSlice(field.Type(), len(values), len(values))
+		for i, value := range values {
+			if err := setValue(slice.Index(i), []string{value}); err != nil {
+				return err
+			}
+		}
+		field.Set(slice)
+		return nil
+	}
+
+	value := values[0]
+	if field.Type() == timeType {
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return fmt.Errorf("must be a time in the format %s", time.RFC3339)
+		}
+		field.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return errors.New("must be true or false")
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return errors.New("must be an integer")
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return errors.New("must be a non-negative integer")
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return errors.New("must be a number")
+		}
+		field.SetFloat(n)
+	default:
+		return fmt.Errorf("can not be decoded into %s", field.Type())
+	}
+
+	return nil
+}
@@ -1,54 +1,295 @@
 package validator
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"strings"
 )
 
-type field interface {
-	_parse(*[]Error) bool
+// Field is a field bound to a value, as returned by String(&value, "name") and the other constructors.
+// Implement it to use a custom field type in StructField.Fields and When.
+// ParseTo adds the errors of the field to the Collector and returns false if there were any.
+// It should stop and return false once 'ctx' is done.
+type Field interface {
+	ParseTo(ctx context.Context, c *Collector) bool
 }
 
+// Schema parses values it is not bound to, like the field types declared with a nil pointer.
+// Implement it to use a custom field type in SliceField.Each, MapField.Keys, MapField.Values and Member.
+// ParseValueTo parses 'value', which may be nil, adds the errors to the Collector and returns false if there were any.
+type Schema[T any] interface {
+	ParseValueTo(ctx context.Context, value *T, c *Collector) bool
+}
+
+// Collector collects the errors of a parse. The zero value is ready to use.
+//
+// Fields add their errors with a path relative to themselves, usually just their name.
+// Parents then prefix the paths of the errors added by their children, for example:
+//
+//	start := c.Len()
+//	ok := child.ParseTo(ctx, c)
+//	c.Prefix(start, PathSegment{Kind: SegmentField, Name: "address"})
+//
+// Fields that parse several values or run several steps should check Done before each of them.
+type Collector struct {
+	errs []Error
+	// limit is the maximum number of errors of the parse, set with WithMaxErrors. Zero means no limit.
+	limit     int
+	truncated bool
+}
+
+func newCollector(opts []ParseOption) Collector {
+	return Collector{limit: newParseOptions(opts).maxErrors}
+}
+
+// Add adds errors to the collector. Errors over the limit of the parse are dropped.
+func (c *Collector) Add(errs ...Error) {
+	if c.limit > 0 && len(c.errs)+len(errs) > c.limit {
+		errs = errs[:max(c.limit-len(c.errs), 0)]
+		c.truncated = true
+	}
+
+	c.errs = append(c.errs, errs...)
+}
+
+// Done tells if the parse should stop, because 'ctx' is done or the errors reached the limit of the parse.
+// In the latter case the parse is reported as truncated, since the rest of it was skipped.
+func (c *Collector) Done(ctx context.Context) bool {
+	if ctx.Err() != nil {
+		return true
+	}
+
+	if c.full() {
+		c.truncated = true
+		return true
+	}
+
+	return false
+}
+
+func (c *Collector) full() bool {
+	return c.limit > 0 && len(c.errs) >= c.limit
+}
+
+// remaining returns the number of errors that can still be added, or 0 if there is no limit.
+// It is the limit of the collectors that parse part of the value on their own.
+func (c *Collector) remaining() int {
+	if c.limit > 0 {
+		return max(c.limit-len(c.errs), 1)
+	}
+
+	return 0
+}
+
+// merge adds the errors of a collector that parsed part of the value on its own, like an action of a Concurrent struct.
+func (c *Collector) merge(other *Collector) {
+	c.Add(other.errs...)
+	c.truncated = c.truncated || other.truncated
+}
+
+// AddError adds an error for the field named 'name', which is empty for unnamed fields.
+func (c *Collector) AddError(name, message, code string) {
+	c.Add(newError(name, message, code))
+}
+
+// Len returns the number of errors collected so far
+func (c *Collector) Len() int {
+	return len(c.errs)
+}
+
+// Errors returns the collected errors
+func (c *Collector) Errors() []Error {
+	return c.errs
+}
+
+// Prefix prepends 'prefix' to the paths of the errors added since the collector held 'start' errors.
+func (c *Collector) Prefix(start int, prefix ...PathSegment) {
+	prefixErrors(c.errs[start:], prefix...)
+}
+
+// Error describes a failed rule.
+// Field is the path to the field as a string, for example 'user.addresses[0].city',
+// and Path holds the same path as a list of segments.
 type Error struct {
 	Field   string
 	Message string
 	Code    string
+	Path    []PathSegment
+	// Params holds the arguments of the rule, for example {"min": 8, "actual": 5} for a failed Min(8),
+	// so that clients can build their own messages. It is nil for rules without arguments.
+	Params map[string]any
+
+	msg *message
+	// truncated marks the last error of a parse that stopped at its error limit, see Errors.Truncated
+	truncated bool
+}
+
+type PathSegmentKind int
+
+const (
+	// SegmentField is the name of a field
+	SegmentField PathSegmentKind = iota
+	// SegmentIndex is the index of a slice item
+	SegmentIndex
+	// SegmentKey is the key of a map entry
+	SegmentKey
+)
+
+// PathSegment is a single step in the path to a field.
+// Depending on the Kind, either Name, Index or Key is set.
+type PathSegment struct {
+	Kind  PathSegmentKind
+	Name  string
+	Index int
+	Key   any
+}
+
+// String formats the segment the way it appears in Error.Field
+func (s PathSegment) String() string {
+	switch s.Kind {
+	case SegmentIndex:
+		return fmt.Sprintf("[%d]", s.Index)
+	case SegmentKey:
+		if key, ok := s.Key.(string); ok {
+			return fmt.Sprintf("[%q]", key)
+		}
+		return fmt.Sprintf("[%v]", s.Key)
+	default:
+		return s.Name
+	}
+}
+
+func formatPath(path []PathSegment) string {
+	var b strings.Builder
+	for i, s := range path {
+		if i > 0 && s.Kind == SegmentField {
+			b.WriteByte('.')
+		}
+		b.WriteString(s.String())
+	}
+
+	return b.String()
+}
+
+// fieldPath returns the path of a field named 'name', which is empty for unnamed fields.
+func fieldPath(name string) []PathSegment {
+	if name == "" {
+		return nil
+	}
+
+	return []PathSegment{{Kind: SegmentField, Name: name}}
+}
+
+func newError(name, message, code string) Error {
+	return Error{Field: name, Message: message, Code: code, Path: fieldPath(name)}
+}
+
+// prefixErrors prepends 'prefix' to the path of every error.
+// Parents call it on the errors of their children, so that paths are built up as the parse unwinds.
+func prefixErrors(errs []Error, prefix ...PathSegment) {
+	if len(prefix) == 0 {
+		return
+	}
+
+	for i := range errs {
+		path := make([]PathSegment, 0, len(prefix)+len(errs[i].Path))
+		path = append(append(path, prefix...), errs[i].Path...)
+		errs[i].Path = path
+		errs[i].Field = formatPath(path)
+	}
 }
 
 type RefinementData struct {
 	Field string
 	Code  string
+	// Independent marks a refinement of a Concurrent struct that doesn't depend on the actions around it,
+	// so that it runs concurrently with them. It receives the value of the struct as it was before they ran.
+	Independent bool
 }
 
 func requiredFieldErr(fieldName, required_err string) Error {
-	var err Error
-	err.Field = fieldName
-	err.Code = CodeRequired
-
-	if required_err != "" {
-		err.Message = required_err
-	} else {
-		err.Message = fmt.Sprintf("%s is required", fieldName)
+	err := newError(fieldName, required_err, CodeRequired)
+	if required_err == "" {
+		err.msg = &message{key: CodeRequired, name: fieldName}
+		err.Message = err.msg.render(DefaultLocale)
 	}
 
 	return err
 }
 
+// parseResult finishes a parse. It reports that the parse stopped early if 'ctx' is done or the errors
+// were truncated, and renders the messages in the locale of the options.
+func parseResult(ctx context.Context, name string, c *Collector, opts []ParseOption) []Error {
+	errs := c.Errors()
+	if err := ctx.Err(); err != nil {
+		code := CodeCanceled
+		if errors.Is(err, context.DeadlineExceeded) {
+			code = CodeDeadlineExceeded
+		}
+
+		errs = append(errs, actionError(name, ruleMessage(nil, code, name, nil), code))
+	}
+
+	if c.truncated && len(errs) > 0 {
+		errs[len(errs)-1].truncated = true
+	}
+
+	return localize(errs, opts)
+}
+
 const (
-	CodeMin          = "min"
-	CodeMax          = "max"
-	CodeLength       = "length"
-	CodeEmail        = "email"
-	CodeUUID         = "uuid"
-	CodeURL          = "url"
-	CodeEndsWith     = "ends-with"
-	CodeStartsWith   = "starts-with"
-	CodeAlpha        = "alpha"
-	CodeNumeric      = "numeric"
-	CodeAlphaNumeric = "alpha-numeric"
-	CodeIsOneOf      = "is-one-of"
-	CodeRefinement   = "refinement"
-	CodeRequired     = "required"
-	CodeContains     = "contains"
-	CodeIs           = "is"
-	CodeInvalidType  = "invalid-type"
+	CodeMin                 = "min"
+	CodeMax                 = "max"
+	CodeLength              = "length"
+	CodeEmail               = "email"
+	CodeUUID                = "uuid"
+	CodeURL                 = "url"
+	CodeURLScheme           = "url-scheme"
+	CodeURLHost             = "url-host"
+	CodeURLCredentials      = "url-credentials"
+	CodeURLPort             = "url-port"
+	CodeURLQuery            = "url-query"
+	CodeURLFragment         = "url-fragment"
+	CodeEndsWith            = "ends-with"
+	CodeStartsWith          = "starts-with"
+	CodeAlpha               = "alpha"
+	CodeNumeric             = "numeric"
+	CodeAlphaNumeric        = "alpha-numeric"
+	CodeIsOneOf             = "is-one-of"
+	CodeRefinement          = "refinement"
+	CodeRequired            = "required"
+	CodeContains            = "contains"
+	CodeIs                  = "is"
+	CodeInvalidType         = "invalid-type"
+	CodePositive            = "positive"
+	CodeNegative            = "negative"
+	CodeNonNegative         = "non-negative"
+	CodeMultipleOf          = "multiple-of"
+	CodeBetween             = "between"
+	CodeInteger             = "integer"
+	CodeFinite              = "finite"
+	CodePrecision           = "precision"
+	CodeBefore              = "before"
+	CodeAfter               = "after"
+	CodeInFuture            = "in-future"
+	CodeInPast              = "in-past"
+	CodeWeekday             = "weekday"
+	CodeNotZero             = "not-zero"
+	CodeDuplicateKey        = "duplicate-key"
+	CodeExcluded            = "excluded"
+	CodeEqualField          = "equal-field"
+	CodeDifferentField      = "different-field"
+	CodeLessThanField       = "less-than-field"
+	CodeLessOrEqualField    = "less-or-equal-field"
+	CodeGreaterThanField    = "greater-than-field"
+	CodeGreaterOrEqualField = "greater-or-equal-field"
+	// CodeCanceled is reported when the context passed to ParseCtx is canceled before the parse completes
+	CodeCanceled = "canceled"
+	// CodeDeadlineExceeded is reported when the deadline of the context passed to ParseCtx passes before the parse completes
+	CodeDeadlineExceeded = "deadline-exceeded"
+	// CodeNoMatch is reported when a value matches none of the fields passed to OneOf
+	CodeNoMatch = "no-match"
+	// CodeUnknownDiscriminator is reported when Discriminated has no field for the value of the discriminator
+	CodeUnknownDiscriminator = "unknown-discriminator"
 )
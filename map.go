@@ -1,90 +1,111 @@
 package validator
 
 import (
-	"errors"
+	"cmp"
+	"context"
 	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
 )
 
-type mapAction[T comparable, K any] struct {
-	validator      func() error
-	refinement     func(map[T]K) error
-	transformer    func(map[T]K)
-	code           string
-	refinementData RefinementData
-}
-
 type MapField[T comparable, K any] struct {
-	value         *map[T]K
-	name          string
-	optional      bool
-	requiredError string
-	actions       []mapAction[T, K]
-	abortEarly    bool
+	fieldCore[map[T]K]
 }
 
-func (f *MapField[T, K]) addValidation(fn func() error, code string) {
-	r := mapAction[T, K]{validator: fn, code: code}
-	f.actions = append(f.actions, r)
-}
+func (f *MapField[T, K]) ParseTo(ctx context.Context, errs *Collector) bool {
+	value, ok, done := f.boundValue(errs)
+	if done {
+		return ok
+	}
 
-func (f *MapField[T, K]) addRefinement(fn func(map[T]K) error, refinementData RefinementData) {
-	r := mapAction[T, K]{refinement: fn, refinementData: refinementData}
-	f.actions = append(f.actions, r)
+	return f.ParseValueTo(ctx, value, errs)
 }
 
-func (f *MapField[T, K]) addTransformer(fn func(map[T]K)) {
-	r := mapAction[T, K]{transformer: fn}
-	f.actions = append(f.actions, r)
+func (f *MapField[T, K]) ParseValueTo(ctx context.Context, value *map[T]K, errs *Collector) bool {
+	return f.parse(ctx, value, errs)
 }
 
-func (f *MapField[T, K]) _parse(errs *[]Error) bool {
-	if f.value == nil {
-		if !f.optional {
-			*errs = append(*errs, requiredFieldErr(f.name, f.requiredError))
-			return false
-		}
-
-		return true
+// parseEntries parses the keys or the values of the map in sorted key order so that errors are stable.
+func (f *MapField[T, K]) parseEntries(ctx context.Context, m map[T]K, keys Schema[T], values Schema[K], errs *Collector) bool {
+	sorted := make([]T, 0, len(m))
+	for key := range m {
+		sorted = append(sorted, key)
 	}
+	slices.SortFunc(sorted, compareKeys[T])
 
-	isFieldParsedSuccessfully := true
-	for _, action := range f.actions {
-		isActionParsedSuccessfully := true
+	ok := true
+	for _, key := range sorted {
+		if errs.Done(ctx) {
+			return false
+		}
 
-		if action.validator != nil {
-			err := action.validator()
-			if err != nil {
-				isActionParsedSuccessfully = false
-				*errs = append(*errs, Error{Field: f.name, Message: err.Error(), Code: action.code})
+		start := errs.Len()
+		entryOk := true
+
+		// keys that are not equal to themselves, like NaN, can't be looked up, so their entries are left as they are
+		writable := key == key
+
+		if keys != nil {
+			newKey := key
+			entryOk = keys.ParseValueTo(ctx, &newKey, errs)
+			if _, exists := m[newKey]; exists && newKey != key {
+				// the transformed key would overwrite another entry, so the map is left unchanged
+				params := map[string]any{"key": newKey}
+				errs.Add(actionError("", ruleMessage(nil, CodeDuplicateKey, f.name, params), CodeDuplicateKey))
+				entryOk = false
+			} else if writable && newKey != key {
+				m[newKey] = m[key]
+				delete(m, key)
 			}
-		} else if action.refinement != nil {
-			err := action.refinement(*f.value)
-			if err != nil {
-				isActionParsedSuccessfully = false
-				me := Error{Field: f.name, Message: err.Error(), Code: CodeRefinement}
-				if action.refinementData.Field != "" {
-					me.Field = action.refinementData.Field
-				}
-				if action.refinementData.Code != "" {
-					me.Code = action.refinementData.Code
-				}
-
-				*errs = append(*errs, me)
+		} else {
+			value := m[key]
+			entryOk = values.ParseValueTo(ctx, &value, errs)
+			if writable {
+				m[key] = value
 			}
-		} else if action.transformer != nil {
-			action.transformer(*f.value)
-			continue
 		}
 
-		if !isActionParsedSuccessfully {
-			isFieldParsedSuccessfully = false
+		path := append(fieldPath(f.name), PathSegment{Kind: SegmentKey, Key: key})
+		nameErrors(errs.Errors()[start:], formatPath(path))
+		errs.Prefix(start, path...)
+
+		if !entryOk {
+			ok = false
 			if f.abortEarly {
 				return false
 			}
 		}
 	}
 
-	return isFieldParsedSuccessfully
+	return ok
+}
+
+// compareKeys orders numbers and strings by value and every other key by its formatted value.
+// Keys of different types, which an interface key type allows, are ordered by the name of their type first.
+func compareKeys[T comparable](a, b T) int {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if !va.IsValid() || !vb.IsValid() || va.Type() != vb.Type() {
+		if c := strings.Compare(fmt.Sprintf("%T", a), fmt.Sprintf("%T", b)); c != 0 {
+			return c
+		}
+
+		return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	}
+
+	switch {
+	case va.CanInt():
+		return cmp.Compare(va.Int(), vb.Int())
+	case va.CanUint():
+		return cmp.Compare(va.Uint(), vb.Uint())
+	case va.CanFloat():
+		return cmp.Compare(va.Float(), vb.Float())
+	case va.Kind() == reflect.String:
+		return cmp.Compare(va.String(), vb.String())
+	}
+
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
 }
 
 // AbortEarly stops the parsing of the field on the first error
@@ -105,21 +126,40 @@ func (f *MapField[T, K]) RequiredError(message string) *MapField[T, K] {
 	return f
 }
 
+// Default sets the value of the field to 'value' when it is missing, before the rules run.
+// A value is missing when its Patch is absent or null, or when it is empty with EmptyAsMissing, see FromPatch.
+// A nil pointer can't hold the default, so it is still required unless the field is Optional.
+func (f *MapField[T, K]) Default(value map[T]K) *MapField[T, K] {
+	return f.DefaultFunc(func() map[T]K { return value })
+}
+
+// DefaultFunc is like Default, but calls 'fn' for the default every time the value is missing.
+func (f *MapField[T, K]) DefaultFunc(fn func() map[T]K) *MapField[T, K] {
+	f.defaultValue = fn
+	return f
+}
+
+// Nullable accepts null values, a nil pointer or a null Patch, and skips the rules for them.
+// Without it, null counts as missing.
+func (f *MapField[T, K]) Nullable() *MapField[T, K] {
+	f.nullable = true
+	return f
+}
+
+// EmptyAsMissing treats the zero value of the field, or an empty slice or map, as missing.
+// It then gets the default, or is reported as required unless the field is Optional.
+func (f *MapField[T, K]) EmptyAsMissing() *MapField[T, K] {
+	f.emptyAsMissing = true
+	return f
+}
+
 // Min sets the minimum number of entries the map should have.
 func (f *MapField[T, K]) Min(size int, message ...string) *MapField[T, K] {
-	fv := *f.value
 	code := CodeMin
 
-	validator := func() error {
+	validator := func(fv map[T]K) error {
 		if len(fv) < size {
-			var msg string
-			if len(message) > 0 {
-				msg = message[0]
-			} else {
-				msg = fmt.Sprintf("%s should have atleast %d entries", f.name, size)
-			}
-
-			return errors.New(msg)
+			return ruleMessage(message, CodeMin+".map", f.name, map[string]any{"min": size, "actual": len(fv)})
 		}
 
 		return nil
@@ -131,19 +171,11 @@ func (f *MapField[T, K]) Min(size int, message ...string) *MapField[T, K] {
 
 // Max sets the maximum number of entries for the map
 func (f *MapField[T, K]) Max(size int, message ...string) *MapField[T, K] {
-	fv := *f.value
 	code := CodeMax
 
-	validator := func() error {
+	validator := func(fv map[T]K) error {
 		if len(fv) > size {
-			var msg string
-			if len(message) > 0 {
-				msg = message[0]
-			} else {
-				msg = fmt.Sprintf("%s can have atmost %d entries", f.name, size)
-			}
-
-			return errors.New(msg)
+			return ruleMessage(message, CodeMax+".map", f.name, map[string]any{"max": size, "actual": len(fv)})
 		}
 
 		return nil
@@ -153,8 +185,75 @@ func (f *MapField[T, K]) Max(size int, message ...string) *MapField[T, K] {
 	return f
 }
 
+// Keys parses every key of the map with the provided schema.
+// Errors of a key are reported with the key, for example 'address["zip"]'.
+// A key transformed into another key of the map is reported with CodeDuplicateKey and left as it is.
+func (f *MapField[T, K]) Keys(schema Schema[T]) *MapField[T, K] {
+	f.addNested(func(ctx context.Context, value *map[T]K, errs *Collector) bool {
+		return f.parseEntries(ctx, *value, schema, nil, errs)
+	})
+	return f
+}
+
+// Values parses every value of the map with the provided schema.
+// Errors of a value are reported with its key, for example 'address["zip"]'.
+func (f *MapField[T, K]) Values(schema Schema[K]) *MapField[T, K] {
+	f.addNested(func(ctx context.Context, value *map[T]K, errs *Collector) bool {
+		return f.parseEntries(ctx, *value, nil, schema, errs)
+	})
+	return f
+}
+
+// When adds the rules of 'then' to the field, but only applies them if 'cond' returns true when the field is parsed.
+// 'then' receives a new field with the same name to add the rules to.
+func (f *MapField[T, K]) When(cond func() bool, then func(*MapField[T, K])) *MapField[T, K] {
+	sub := Map[T, K](nil, f.name)
+	then(sub)
+
+	f.dependent = true
+	f.addNested(func(ctx context.Context, value *map[T]K, errs *Collector) bool {
+		return !cond() || sub.ParseValueTo(ctx, value, errs)
+	})
+	return f
+}
+
+// Unless is like When, but applies the rules of 'then' only if 'cond' returns false
+func (f *MapField[T, K]) Unless(cond func() bool, then func(*MapField[T, K])) *MapField[T, K] {
+	return f.When(func() bool { return !cond() }, then)
+}
+
+// RequiredIf makes the field required if 'cond' returns true when the field is parsed, and optional otherwise.
+// Nil and zero values count as missing, and the rules of a missing optional field are skipped.
+func (f *MapField[T, K]) RequiredIf(cond func() bool) *MapField[T, K] {
+	f.presence.requireIf(cond)
+	return f
+}
+
+// RequiredUnless makes the field required if 'cond' returns false, see RequiredIf
+func (f *MapField[T, K]) RequiredUnless(cond func() bool) *MapField[T, K] {
+	return f.RequiredIf(func() bool { return !cond() })
+}
+
+// RequiredWith makes the field required if any of the values pointed to by 'others' is present, see RequiredIf
+func (f *MapField[T, K]) RequiredWith(others ...any) *MapField[T, K] {
+	return f.RequiredIf(func() bool { return anyPresent(others) })
+}
+
+// ExcludedWith reports the field with CodeExcluded if it is present while any of the values pointed to by 'others' is.
+// The rules of the field are skipped while it is excluded.
+func (f *MapField[T, K]) ExcludedWith(others ...any) *MapField[T, K] {
+	f.presence.excludeIf(func() bool { return anyPresent(others) })
+	return f
+}
+
 // Refine lets you provide custom validation logic
 func (f *MapField[T, K]) Refine(fn func(map[T]K) error, refinementData ...RefinementData) *MapField[T, K] {
+	return f.RefineCtx(func(_ context.Context, value map[T]K) error { return fn(value) }, refinementData...)
+}
+
+// RefineCtx is like Refine, but 'fn' also receives the context passed to ParseCtx.
+// An error returned after the context is done is not reported as a refinement error.
+func (f *MapField[T, K]) RefineCtx(fn func(context.Context, map[T]K) error, refinementData ...RefinementData) *MapField[T, K] {
 	var newRefinementData RefinementData
 	if len(refinementData) > 0 {
 		newRefinementData = refinementData[0]
@@ -166,27 +265,53 @@ func (f *MapField[T, K]) Refine(fn func(map[T]K) error, refinementData ...Refine
 
 // Transform "transforms" the field value.
 func (f *MapField[T, K]) Transform(fn func(map[T]K)) *MapField[T, K] {
-	f.addTransformer(fn)
+	f.addTransformer(func(m map[T]K) map[T]K {
+		fn(m)
+		return m
+	})
 	return f
 }
 
 // Parse parses the field and returns a slice of Error.
-func (f *MapField[T, K]) Parse() []Error {
-	var errs []Error
-	f._parse(&errs)
-	return errs
+func (f *MapField[T, K]) Parse(opts ...ParseOption) []Error {
+	return f.ParseCtx(context.Background(), opts...)
+}
+
+// ParseCtx parses the field like Parse and passes 'ctx' to the refinements added with RefineCtx.
+// When 'ctx' is done the parse stops and a CodeCanceled or CodeDeadlineExceeded error is reported.
+func (f *MapField[T, K]) ParseCtx(ctx context.Context, opts ...ParseOption) []Error {
+	errs := newCollector(opts)
+	f.ParseTo(ctx, &errs)
+	return parseResult(ctx, f.name, &errs, opts)
+}
+
+// ParseValue parses the provided value instead of the bound one and returns a slice of Error.
+func (f *MapField[T, K]) ParseValue(value *map[T]K, opts ...ParseOption) []Error {
+	return f.ParseValueCtx(context.Background(), value, opts...)
+}
+
+// ParseValueCtx parses the provided value like ParseValue, with the context handling of ParseCtx.
+func (f *MapField[T, K]) ParseValueCtx(ctx context.Context, value *map[T]K, opts ...ParseOption) []Error {
+	errs := newCollector(opts)
+	f.ParseValueTo(ctx, value, &errs)
+	return parseResult(ctx, f.name, &errs, opts)
+}
+
+// Validate parses the field and returns the errors as Errors, or nil if the field is valid.
+func (f *MapField[T, K]) Validate(opts ...ParseOption) error {
+	return asError(f.Parse(opts...))
+}
+
+// ValidateValue parses the provided value like ParseValue and returns the errors as Errors, or nil if the value is valid.
+func (f *MapField[T, K]) ValidateValue(value *map[T]K, opts ...ParseOption) error {
+	return asError(f.ParseValue(value, opts...))
 }
 
 // Map takes a pointer to a map and a variadic argument 'name'.
 // Even if multiple values are passed for 'name', only the first value will be considered.
+// Pass a nil pointer to declare a reusable schema and apply it with ParseValue.
 func Map[T comparable, K any](value *map[T]K, name ...string) *MapField[T, K] {
-	field := MapField[T, K]{
-		value: value,
-	}
-
-	if len(name) > 0 {
-		field.name = name[0]
-	}
-
-	return &field
+	f := &MapField[T, K]{fieldCore: newFieldCore(value, name)}
+	f.clone = maps.Clone[map[T]K]
+	return f
 }
@@ -2,6 +2,7 @@ package validator
 
 import (
 	"errors"
+	"math"
 	"testing"
 )
 
@@ -93,3 +94,127 @@ func TestMapTransform(t *testing.T) {
 		}
 	}
 }
+
+func TestMapTransformBeforeValidation(t *testing.T) {
+	input := map[string]int{"a": 1, "b": 2}
+
+	errs := Map(&input).
+		Transform(func(m map[string]int) {
+			m["c"] = 3
+		}).
+		Min(3).
+		Parse()
+
+	if len(errs) > 0 {
+		t.Error("expected no error")
+	}
+}
+
+func TestMapOptionalNilPointer(t *testing.T) {
+	var input *map[string]int
+
+	errs := Map(input).Optional().Min(1).Max(3).Parse()
+	if len(errs) > 0 {
+		t.Error("expected no error")
+	}
+}
+
+func TestMapKeys(t *testing.T) {
+	goodInput := map[string]int{"a": 1, "b": 2}
+	badInput := map[string]int{"abc": 1, "b": 2, "cd": 3}
+
+	errs := Map(&goodInput).Keys(String(nil).Length(1)).Parse()
+	if len(errs) > 0 {
+		t.Error("expected no error")
+	}
+
+	errs = Map(&badInput, "scores").Keys(String(nil).Length(1)).Parse()
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %d", len(errs))
+	}
+
+	if errs[0].Field != `scores["abc"]` || errs[1].Field != `scores["cd"]` {
+		t.Errorf("unexpected fields %q and %q", errs[0].Field, errs[1].Field)
+	}
+
+	if errs[0].Message != `scores["abc"] should have 1 characters` {
+		t.Errorf("expected the unnamed key to be named by its key, got %q", errs[0].Message)
+	}
+}
+
+func TestMapKeysMixedTypes(t *testing.T) {
+	input := map[any]int{1: 1, "x": 2, 2.5: 3, nil: 4}
+
+	errs := Map(&input, "mixed").Values(Number[int](nil).Min(1)).Parse()
+	if len(errs) > 0 {
+		t.Errorf("expected no error, got %v", errs)
+	}
+}
+
+func TestMapKeysCollision(t *testing.T) {
+	input := map[string]int{"A": 1, "a": 2}
+
+	errs := Map(&input, "scores").Keys(String(nil).ToLowerCase()).Parse()
+	if len(errs) != 1 || errs[0].Field != `scores["A"]` || errs[0].Code != CodeDuplicateKey {
+		t.Fatalf("expected a duplicate key error for A, got %v", errs)
+	}
+
+	if len(input) != 2 || input["A"] != 1 || input["a"] != 2 {
+		t.Errorf("expected the map to be left unchanged, got %v", input)
+	}
+}
+
+func TestMapValuesNaNKey(t *testing.T) {
+	input := map[float64]string{math.NaN(): " a ", 1: " b "}
+
+	for i := 0; i < 3; i++ {
+		if errs := Map(&input).Values(String(nil).TrimSpace()).Parse(); len(errs) > 0 {
+			t.Fatalf("expected no error, got %v", errs)
+		}
+	}
+
+	if len(input) != 2 || input[1] != "b" {
+		t.Errorf("expected no entries to be added, got %v", input)
+	}
+}
+
+func TestMapValues(t *testing.T) {
+	input := map[int]string{10: "  zip  ", 9: "", 2: "city"}
+
+	errs := Map(&input, "address").Values(String(nil).TrimSpace().Min(1)).Parse()
+	if len(errs) != 1 || errs[0].Field != "address[9]" {
+		t.Fatalf("expected error for address[9], got %v", errs)
+	}
+
+	if errs[0].Message != "address[9] should have atleast 1 characters" {
+		t.Errorf("expected the unnamed value to be named by its key, got %q", errs[0].Message)
+	}
+
+	if input[10] != "zip" {
+		t.Error("value not transformed properly")
+	}
+
+	input[9] = ""
+	input[1] = ""
+	errs = Map(&input, "address").Values(String(nil).Min(1)).AbortEarly().Parse()
+	if len(errs) != 1 || errs[0].Field != "address[1]" {
+		t.Errorf("expected a single error for address[1], got %v", errs)
+	}
+}
+
+func TestMapValuesStruct(t *testing.T) {
+
+	type Address struct {
+		zip string
+	}
+
+	input := map[string]Address{"home": {zip: "12345"}, "work": {zip: "12a45"}}
+
+	errs := Map(&input, "addresses").
+		Values(Struct[Address](nil).Members(Member(func(a *Address) *string { return &a.zip }, String(nil, "zip").Numeric()))).
+		Parse()
+
+	if len(errs) != 1 || errs[0].Field != `addresses["work"].zip` {
+		t.Errorf("expected error for addresses[\"work\"].zip, got %v", errs)
+	}
+}
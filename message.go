@@ -0,0 +1,380 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultLocale is the locale of the messages when Parse is called without WithLocale
+const DefaultLocale = "en"
+
+// Catalog maps message keys to the message templates of a locale.
+// A key is a Code, optionally followed by a dot and a qualifier when the message depends on the kind of field,
+// for example "min.string" or "min.slice". Messages are looked up by the full key first and then by the Code alone.
+// Templates refer to params by name, as in "{field} must be atleast {min}", where {field} is the name of the field.
+type Catalog map[string]string
+
+var (
+	catalogsMu sync.RWMutex
+	catalogs   = map[string]Catalog{
+		"en": englishCatalog,
+		"de": germanCatalog,
+	}
+)
+
+// RegisterCatalog adds the messages of 'catalog' to the locale, replacing the messages with the same keys.
+// Registering a new locale creates it. Messages missing in a locale fall back to DefaultLocale.
+func RegisterCatalog(locale string, catalog Catalog) {
+	catalogsMu.Lock()
+	defer catalogsMu.Unlock()
+
+	merged := make(Catalog, len(catalogs[locale])+len(catalog))
+	for key, tmpl := range catalogs[locale] {
+		merged[key] = tmpl
+	}
+	for key, tmpl := range catalog {
+		merged[key] = tmpl
+	}
+
+	catalogs[locale] = merged
+}
+
+// ParseOption configures a call to Parse, ParseValue, Validate or ValidateValue.
+type ParseOption func(*parseOptions)
+
+type parseOptions struct {
+	locale    string
+	maxErrors int
+}
+
+// WithLocale renders the messages of the errors from the catalog of the locale, see RegisterCatalog.
+// Messages passed to the rules are never translated.
+func WithLocale(locale string) ParseOption {
+	return func(o *parseOptions) {
+		o.locale = locale
+	}
+}
+
+// WithMaxErrors stops the whole parse once 'n' errors were reported, including the errors of nested fields,
+// slice items and map entries. The remaining rules are skipped, which Errors.Truncated reports.
+// A limit of zero or less means no limit.
+func WithMaxErrors(n int) ParseOption {
+	return func(o *parseOptions) {
+		o.maxErrors = n
+	}
+}
+
+// WithFailFast stops the whole parse at the first error, like WithMaxErrors(1).
+// Unlike AbortEarly, it applies to every field of the parse.
+func WithFailFast() ParseOption {
+	return WithMaxErrors(1)
+}
+
+func newParseOptions(opts []ParseOption) parseOptions {
+	o := parseOptions{locale: DefaultLocale}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// message is the catalog entry an Error is rendered from.
+// It is kept on the Error so that the message can be rendered again in the locale of the Parse call.
+type message struct {
+	key    string
+	name   string
+	params map[string]any
+}
+
+func (m message) render(locale string) string {
+	catalogsMu.RLock()
+	tmpl, ok := lookupMessage(catalogs[locale], m.key)
+	if !ok {
+		tmpl, ok = lookupMessage(catalogs[DefaultLocale], m.key)
+	}
+	catalogsMu.RUnlock()
+
+	if !ok {
+		tmpl = "{field} is not valid"
+	}
+
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(tmpl, '{')
+		end := -1
+		if start >= 0 {
+			end = strings.IndexByte(tmpl[start:], '}')
+		}
+		if end < 0 {
+			b.WriteString(tmpl)
+			return b.String()
+		}
+		end += start
+
+		b.WriteString(tmpl[:start])
+		param := tmpl[start+1 : end]
+		if param == "field" {
+			b.WriteString(m.name)
+		} else if value, ok := m.params[param]; ok {
+			b.WriteString(formatParam(value))
+		} else {
+			b.WriteString(tmpl[start : end+1])
+		}
+		tmpl = tmpl[end+1:]
+	}
+}
+
+func lookupMessage(catalog Catalog, key string) (string, bool) {
+	if tmpl, ok := catalog[key]; ok {
+		return tmpl, true
+	}
+
+	code, _, found := strings.Cut(key, ".")
+	if !found {
+		return "", false
+	}
+
+	tmpl, ok := catalog[code]
+	return tmpl, ok
+}
+
+// formatParam formats times as RFC 3339 and lists as comma separated values.
+func formatParam(value any) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case fmt.Stringer:
+		return v.String()
+	case string:
+		return v
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Slice {
+		s := make([]string, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			s = append(s, formatParam(rv.Index(i).Interface()))
+		}
+
+		return strings.Join(s, ", ")
+	}
+
+	return fmt.Sprint(value)
+}
+
+// ruleError is returned by the validators of the built-in rules.
+// Its message is the one passed to the rule, if any, or else it is rendered from the catalog.
+// code replaces the code of the rule when it is set.
+type ruleError struct {
+	code   string
+	custom string
+	message
+}
+
+func (e ruleError) Error() string {
+	if e.custom != "" {
+		return e.custom
+	}
+
+	return e.render(DefaultLocale)
+}
+
+// ruleMessage returns the error of a failed rule, with the message passed to the rule if there is one
+// or else the message of 'key' from the catalog.
+func ruleMessage(custom []string, key, name string, params map[string]any) error {
+	err := ruleError{message: message{key: key, name: name, params: params}}
+	if len(custom) > 0 {
+		err.custom = custom[0]
+	}
+
+	return err
+}
+
+// actionError builds the Error reported when the validator of an action fails.
+func actionError(name string, err error, code string) Error {
+	me := newError(name, err.Error(), code)
+	if e, ok := err.(ruleError); ok {
+		if e.code != "" {
+			me.Code = e.code
+		}
+		if e.custom == "" {
+			me.msg = &e.message
+		}
+		me.Params = e.params
+	}
+
+	return me
+}
+
+// nameErrors renders the messages of the errors reported without a field name with 'name',
+// like the errors of the unnamed schemas of the items of slices and maps.
+func nameErrors(errs []Error, name string) {
+	for i := range errs {
+		if m := errs[i].msg; m != nil && m.name == "" {
+			named := *m
+			named.name = name
+			errs[i].msg = &named
+			errs[i].Message = named.render(DefaultLocale)
+		}
+	}
+}
+
+// localize renders the messages of the errors again in the locale of the options.
+func localize(errs []Error, opts []ParseOption) []Error {
+	o := newParseOptions(opts)
+	if o.locale == DefaultLocale {
+		return errs
+	}
+
+	for i := range errs {
+		if errs[i].msg != nil {
+			errs[i].Message = errs[i].msg.render(o.locale)
+		}
+	}
+
+	return errs
+}
+
+var englishCatalog = Catalog{
+	CodeRequired:                 "{field} is required",
+	CodeExcluded:                 "{field} must not be set",
+	CodeInvalidType + ".integer": `{field} must be an integer, got "{value}"`,
+	CodeInvalidType + ".number":  `{field} must be a number, got "{value}"`,
+	CodeInvalidType + ".range":   `{field} is out of range, got "{value}"`,
+	CodeInvalidType + ".bool":    `{field} must be true or false, got "{value}"`,
+	CodeInvalidType + ".time":    `{field} must be a time in the format {layout}, got "{value}"`,
+
+	CodeMin + ".string":        "{field} should have atleast {min} characters",
+	CodeMax + ".string":        "{field} can have atmost {max} characters",
+	CodeLength + ".string":     "{field} should have {length} characters",
+	CodeContains:               "{field} should contain {substring}",
+	CodeEmail:                  "{field} is not a valid email",
+	CodeUUID:                   "{field} is not a valid UUID",
+	CodeURL:                    "{field} is not a valid URL",
+	CodeURLScheme:              "{field} must use one of the schemes {schemes}",
+	CodeURLHost:                "{field} must have a host",
+	CodeURLCredentials:         "{field} must not contain credentials",
+	CodeURLPort:                "{field} has an invalid port",
+	CodeURLPort + ".forbidden": "{field} must not contain a port",
+	CodeURLQuery:               "{field} must not contain a query string",
+	CodeURLFragment:            "{field} must not contain a fragment",
+	CodeEndsWith:               "{field} does not ends with {suffix}",
+	CodeStartsWith:             "{field} does not starts with {prefix}",
+	CodeAlpha:                  "{field} should contain only alphabets",
+	CodeNumeric:                "{field} should contain only numbers",
+	CodeAlphaNumeric:           "{field} should contain only alphabets and numbers",
+	CodeIsOneOf:                "{field} can only be {allowed}",
+
+	CodeMin:         "{field} must be atleast {min}",
+	CodeMax:         "{field} can be atmost {max}",
+	CodePositive:    "{field} must be positive",
+	CodeNegative:    "{field} must be negative",
+	CodeNonNegative: "{field} must not be negative",
+	CodeMultipleOf:  "{field} must be a multiple of {step}",
+	CodeBetween:     "{field} must be between {min} and {max} ({bounds})",
+	CodeInteger:     "{field} must be an integer",
+	CodeFinite:      "{field} must be a finite number",
+	CodePrecision:   "{field} can have atmost {decimals} decimal places",
+
+	CodeIs: "{field} should be {expected}",
+
+	CodeMin + ".slice":    "{field} must have atleast {min} items",
+	CodeMax + ".slice":    "{field} must have atmost {max} items",
+	CodeLength + ".slice": "{field} must have {length} items",
+	CodeMin + ".map":      "{field} should have atleast {min} entries",
+	CodeMax + ".map":      "{field} can have atmost {max} entries",
+	CodeDuplicateKey:      "{field} already has the key {key}",
+
+	CodeBefore:   "{field} must be before {before}",
+	CodeAfter:    "{field} must be after {after}",
+	CodeInFuture: "{field} must be in the future",
+	CodeInPast:   "{field} must be in the past",
+	CodeWeekday:  "{field} must be on {weekdays}",
+	CodeNotZero:  "{field} must be set",
+
+	CodeEqualField:          "{field} must be equal to {other}",
+	CodeDifferentField:      "{field} must be different from {other}",
+	CodeLessThanField:       "{field} must be less than {other}",
+	CodeLessOrEqualField:    "{field} must be less than or equal to {other}",
+	CodeGreaterThanField:    "{field} must be greater than {other}",
+	CodeGreaterOrEqualField: "{field} must be greater than or equal to {other}",
+
+	CodeCanceled:             "validation was canceled",
+	CodeDeadlineExceeded:     "validation did not complete before the deadline",
+	CodeNoMatch:              "value does not match any of the {schemas} allowed schemas",
+	CodeUnknownDiscriminator: "{value} is not a known type, expected one of {allowed}",
+}
+
+var germanCatalog = Catalog{
+	CodeRequired:                 "{field} ist erforderlich",
+	CodeExcluded:                 "{field} darf nicht gesetzt sein",
+	CodeInvalidType + ".integer": `{field} muss eine ganze Zahl sein, erhalten "{value}"`,
+	CodeInvalidType + ".number":  `{field} muss eine Zahl sein, erhalten "{value}"`,
+	CodeInvalidType + ".range":   `{field} liegt außerhalb des gültigen Bereichs, erhalten "{value}"`,
+	CodeInvalidType + ".bool":    `{field} muss true oder false sein, erhalten "{value}"`,
+	CodeInvalidType + ".time":    `{field} muss eine Zeit im Format {layout} sein, erhalten "{value}"`,
+
+	CodeMin + ".string":        "{field} muss mindestens {min} Zeichen haben",
+	CodeMax + ".string":        "{field} darf höchstens {max} Zeichen haben",
+	CodeLength + ".string":     "{field} muss genau {length} Zeichen haben",
+	CodeContains:               "{field} muss {substring} enthalten",
+	CodeEmail:                  "{field} ist keine gültige E-Mail-Adresse",
+	CodeUUID:                   "{field} ist keine gültige UUID",
+	CodeURL:                    "{field} ist keine gültige URL",
+	CodeURLScheme:              "{field} muss eines der Schemata {schemes} verwenden",
+	CodeURLHost:                "{field} muss einen Host haben",
+	CodeURLCredentials:         "{field} darf keine Zugangsdaten enthalten",
+	CodeURLPort:                "{field} hat einen ungültigen Port",
+	CodeURLPort + ".forbidden": "{field} darf keinen Port enthalten",
+	CodeURLQuery:               "{field} darf keinen Query-String enthalten",
+	CodeURLFragment:            "{field} darf kein Fragment enthalten",
+	CodeEndsWith:               "{field} muss mit {suffix} enden",
+	CodeStartsWith:             "{field} muss mit {prefix} beginnen",
+	CodeAlpha:                  "{field} darf nur Buchstaben enthalten",
+	CodeNumeric:                "{field} darf nur Ziffern enthalten",
+	CodeAlphaNumeric:           "{field} darf nur Buchstaben und Ziffern enthalten",
+	CodeIsOneOf:                "{field} muss einer der Werte {allowed} sein",
+
+	CodeMin:         "{field} muss mindestens {min} sein",
+	CodeMax:         "{field} darf höchstens {max} sein",
+	CodePositive:    "{field} muss positiv sein",
+	CodeNegative:    "{field} muss negativ sein",
+	CodeNonNegative: "{field} darf nicht negativ sein",
+	CodeMultipleOf:  "{field} muss ein Vielfaches von {step} sein",
+	CodeBetween:     "{field} muss zwischen {min} und {max} liegen",
+	CodeInteger:     "{field} muss eine ganze Zahl sein",
+	CodeFinite:      "{field} muss eine endliche Zahl sein",
+	CodePrecision:   "{field} darf höchstens {decimals} Nachkommastellen haben",
+
+	CodeIs: "{field} muss {expected} sein",
+
+	CodeMin + ".slice":    "{field} muss mindestens {min} Einträge haben",
+	CodeMax + ".slice":    "{field} darf höchstens {max} Einträge haben",
+	CodeLength + ".slice": "{field} muss genau {length} Einträge haben",
+	CodeMin + ".map":      "{field} muss mindestens {min} Einträge haben",
+	CodeMax + ".map":      "{field} darf höchstens {max} Einträge haben",
+	CodeDuplicateKey:      "{field} hat bereits den Schlüssel {key}",
+
+	CodeBefore:   "{field} muss vor {before} liegen",
+	CodeAfter:    "{field} muss nach {after} liegen",
+	CodeInFuture: "{field} muss in der Zukunft liegen",
+	CodeInPast:   "{field} muss in der Vergangenheit liegen",
+	CodeWeekday:  "{field} muss auf {weekdays} fallen",
+	CodeNotZero:  "{field} muss gesetzt sein",
+
+	CodeEqualField:          "{field} muss mit {other} übereinstimmen",
+	CodeDifferentField:      "{field} muss sich von {other} unterscheiden",
+	CodeLessThanField:       "{field} muss kleiner als {other} sein",
+	CodeLessOrEqualField:    "{field} muss kleiner als oder gleich {other} sein",
+	CodeGreaterThanField:    "{field} muss größer als {other} sein",
+	CodeGreaterOrEqualField: "{field} muss größer als oder gleich {other} sein",
+
+	CodeCanceled:             "die Validierung wurde abgebrochen",
+	CodeDeadlineExceeded:     "die Validierung wurde nicht vor Ablauf der Frist abgeschlossen",
+	CodeNoMatch:              "der Wert entspricht keinem der {schemas} erlaubten Schemas",
+	CodeUnknownDiscriminator: "{value} ist kein bekannter Typ, erwartet wird einer von {allowed}",
+}
@@ -0,0 +1,102 @@
+package validator
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLocale(t *testing.T) {
+	name := "aa"
+
+	errs := String(&name, "name").Min(3).Parse()
+	if len(errs) == 0 || errs[0].Message != "name should have atleast 3 characters" {
+		t.Fatalf("expected english message, got %v", errs)
+	}
+
+	errs = String(&name, "name").Min(3).Parse(WithLocale("de"))
+	if len(errs) == 0 || errs[0].Message != "name muss mindestens 3 Zeichen haben" {
+		t.Errorf("expected german message, got %v", errs)
+	}
+
+	errs = String(&name, "name").Min(3, "name is too short").Parse(WithLocale("de"))
+	if len(errs) == 0 || errs[0].Message != "name is too short" {
+		t.Errorf("expected custom message to be kept, got %v", errs)
+	}
+
+	errs = String(nil, "name").Parse(WithLocale("de"))
+	if len(errs) == 0 || errs[0].Message != "name ist erforderlich" {
+		t.Errorf("expected german required message, got %v", errs)
+	}
+}
+
+func TestLocaleNested(t *testing.T) {
+
+	type Event struct {
+		Tags  []string
+		Start time.Time
+	}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	event := Event{Tags: []string{"a", "b", "c"}, Start: start}
+
+	errs := Struct(&event, "event").
+		Fields(
+			Slice(&event.Tags, "tags").Max(2),
+			Time(&event.Start, "start").After(start.Add(time.Hour)),
+		).
+		Parse(WithLocale("de"))
+
+	expected := []string{
+		"tags darf höchstens 2 Einträge haben",
+		"start muss nach 2024-01-01T01:00:00Z liegen",
+	}
+
+	if len(errs) != len(expected) {
+		t.Fatalf("expected %d errors, got %v", len(expected), errs)
+	}
+
+	for i, msg := range expected {
+		if errs[i].Message != msg {
+			t.Errorf("expected %q, got %q", msg, errs[i].Message)
+		}
+	}
+}
+
+func TestRegisterCatalog(t *testing.T) {
+	t.Cleanup(func() {
+		catalogsMu.Lock()
+		delete(catalogs, "fr")
+		catalogsMu.Unlock()
+	})
+
+	RegisterCatalog("fr", Catalog{
+		CodeMin:            "{field} doit être au moins {min}",
+		CodeMin + ".slice": "{field} doit avoir au moins {min} éléments",
+	})
+
+	age := 15
+	errs := Number(&age, "age").Min(18).Max(10).Parse(WithLocale("fr"))
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %v", errs)
+	}
+
+	if errs[0].Message != "age doit être au moins 18" {
+		t.Errorf("expected french message, got %q", errs[0].Message)
+	}
+
+	if errs[1].Message != "age can be atmost 10" {
+		t.Errorf("expected fallback to english, got %q", errs[1].Message)
+	}
+
+	tags := []string{}
+	errs = Slice(&tags, "tags").Min(1).Parse(WithLocale("fr"))
+	if len(errs) == 0 || errs[0].Message != "tags doit avoir au moins 1 éléments" {
+		t.Errorf("expected qualified key to be used, got %v", errs)
+	}
+
+	RegisterCatalog("fr", Catalog{CodeMax: "{field} doit être au plus {max}"})
+	errs = Number(&age, "age").Min(18).Max(10).Parse(WithLocale("fr"))
+	if errs[0].Message != "age doit être au moins 18" || errs[1].Message != "age doit être au plus 10" {
+		t.Errorf("expected catalogs to be merged, got %v", errs)
+	}
+}
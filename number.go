@@ -1,94 +1,60 @@
 package validator
 
 import (
-	"errors"
-	"fmt"
+	"context"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
 )
 
 type number interface {
-	int8 | int16 | int | int32 | int64 | float32 | float64
+	int8 | int16 | int | int32 | int64 | uint8 | uint16 | uint | uint32 | uint64 | uintptr | float32 | float64
 }
 
-type numberAction[T number] struct {
-	validator      func() error
-	refinement     func(T) error
-	transformer    func(T) T
-	code           string
-	refinementData RefinementData
-}
-
-type NumberField[T number] struct {
-	value         *T
-	name          string
-	optional      bool
-	requiredError string
-	actions       []numberAction[T]
-	abortEarly    bool
-}
-
-func (f *NumberField[T]) addValidation(fn func() error, code string) {
-	action := numberAction[T]{validator: fn, code: code}
-	f.actions = append(f.actions, action)
-}
+// Bounds tells Between if the limits of the range are valid values
+type Bounds int
+
+const (
+	// Inclusive accepts both lo and hi
+	Inclusive Bounds = iota
+	// Exclusive rejects both lo and hi
+	Exclusive
+	// ExclusiveLo rejects lo and accepts hi
+	ExclusiveLo
+	// ExclusiveHi accepts lo and rejects hi
+	ExclusiveHi
+)
 
-func (f *NumberField[T]) addRefinement(fn func(T) error, refinementData RefinementData) {
-	action := numberAction[T]{refinement: fn, refinementData: refinementData}
-	f.actions = append(f.actions, action)
+// String describes the bounds in error messages
+func (b Bounds) String() string {
+	switch b {
+	case Exclusive:
+		return "exclusive"
+	case ExclusiveLo:
+		return "excluding the lower bound"
+	case ExclusiveHi:
+		return "excluding the upper bound"
+	default:
+		return "inclusive"
+	}
 }
 
-func (f *NumberField[T]) addTransformer(fn func(T) T) {
-	action := numberAction[T]{transformer: fn}
-	f.actions = append(f.actions, action)
+type NumberField[T number] struct {
+	fieldCore[T]
 }
 
-func (f *NumberField[T]) _parse(errs *[]Error) bool {
-	if f.value == nil {
-		if !f.optional {
-			*errs = append(*errs, requiredFieldErr(f.name, f.requiredError))
-			return false
-		}
-
-		return true
+func (f *NumberField[T]) ParseTo(ctx context.Context, errs *Collector) bool {
+	value, ok, done := f.boundValue(errs)
+	if done {
+		return ok
 	}
 
-	isFieldParsedSuccessfully := true
-	for _, action := range f.actions {
-		isActionParsedSuccessfully := true
-
-		if action.validator != nil {
-			err := action.validator()
-			if err != nil {
-				isActionParsedSuccessfully = false
-				*errs = append(*errs, Error{Field: f.name, Message: err.Error(), Code: action.code})
-			}
-		} else if action.refinement != nil {
-			err := action.refinement(*f.value)
-			if err != nil {
-				isActionParsedSuccessfully = false
-				me := Error{Field: f.name, Message: err.Error(), Code: CodeRefinement}
-				if action.refinementData.Field != "" {
-					me.Field = action.refinementData.Field
-				}
-				if action.refinementData.Code != "" {
-					me.Code = action.refinementData.Code
-				}
-
-				*errs = append(*errs, me)
-			}
-		} else if action.transformer != nil {
-			*f.value = action.transformer(*f.value)
-			continue
-		}
-
-		if !isActionParsedSuccessfully {
-			isFieldParsedSuccessfully = false
-			if f.abortEarly {
-				return false
-			}
-		}
-	}
+	return f.ParseValueTo(ctx, value, errs)
+}
 
-	return isFieldParsedSuccessfully
+func (f *NumberField[T]) ParseValueTo(ctx context.Context, value *T, errs *Collector) bool {
+	return f.parse(ctx, value, errs)
 }
 
 // AbortEarly stops the parsing of the field on the first error
@@ -109,21 +75,41 @@ func (f *NumberField[T]) RequiredError(message string) *NumberField[T] {
 	return f
 }
 
+// Default sets the value of the field to 'value' when it is missing, before the rules run.
+// A value is missing when its Patch is absent or null, or when it is empty with EmptyAsMissing, see FromPatch.
+// A nil pointer can't hold the default, so it is still required unless the field is Optional.
+// A blank string of a Coerce field is missing, and the default is stored in its target.
+func (f *NumberField[T]) Default(value T) *NumberField[T] {
+	return f.DefaultFunc(func() T { return value })
+}
+
+// DefaultFunc is like Default, but calls 'fn' for the default every time the value is missing.
+func (f *NumberField[T]) DefaultFunc(fn func() T) *NumberField[T] {
+	f.defaultValue = fn
+	return f
+}
+
+// Nullable accepts null values, a nil pointer or a null Patch, and skips the rules for them.
+// Without it, null counts as missing.
+func (f *NumberField[T]) Nullable() *NumberField[T] {
+	f.nullable = true
+	return f
+}
+
+// EmptyAsMissing treats the zero value of the field, or an empty slice or map, as missing.
+// It then gets the default, or is reported as required unless the field is Optional.
+func (f *NumberField[T]) EmptyAsMissing() *NumberField[T] {
+	f.emptyAsMissing = true
+	return f
+}
+
 // Min sets the minimum value for the field.
 func (f *NumberField[T]) Min(value T, message ...string) *NumberField[T] {
-	fv := *f.value
 	code := CodeMin
 
-	validator := func() error {
+	validator := func(fv T) error {
 		if fv < value {
-			var msg string
-			if len(message) > 0 {
-				msg = message[0]
-			} else {
-				msg = fmt.Sprintf("%s must be atleast %v", f.name, value)
-			}
-
-			return errors.New(msg)
+			return ruleMessage(message, CodeMin, f.name, map[string]any{"min": value, "actual": fv})
 		}
 
 		return nil
@@ -135,19 +121,125 @@ func (f *NumberField[T]) Min(value T, message ...string) *NumberField[T] {
 
 // Max sets the maximum value for the field.
 func (f *NumberField[T]) Max(value T, message ...string) *NumberField[T] {
-	fv := *f.value
 	code := CodeMax
 
-	validator := func() error {
+	validator := func(fv T) error {
 		if fv > value {
-			var msg string
-			if len(message) > 0 {
-				msg = message[0]
-			} else {
-				msg = fmt.Sprintf("%s can be atmost %v", f.name, value)
-			}
-
-			return errors.New(msg)
+			return ruleMessage(message, CodeMax, f.name, map[string]any{"max": value, "actual": fv})
+		}
+
+		return nil
+	}
+
+	f.addValidation(validator, code)
+	return f
+}
+
+// Positive checks if the field value is greater than zero
+func (f *NumberField[T]) Positive(message ...string) *NumberField[T] {
+	code := CodePositive
+
+	validator := func(fv T) error {
+		if fv <= 0 {
+			return ruleMessage(message, CodePositive, f.name, nil)
+		}
+
+		return nil
+	}
+
+	f.addValidation(validator, code)
+	return f
+}
+
+// Negative checks if the field value is less than zero
+func (f *NumberField[T]) Negative(message ...string) *NumberField[T] {
+	code := CodeNegative
+
+	validator := func(fv T) error {
+		if fv >= 0 {
+			return ruleMessage(message, CodeNegative, f.name, nil)
+		}
+
+		return nil
+	}
+
+	f.addValidation(validator, code)
+	return f
+}
+
+// NonNegative checks if the field value is zero or greater
+func (f *NumberField[T]) NonNegative(message ...string) *NumberField[T] {
+	code := CodeNonNegative
+
+	validator := func(fv T) error {
+		if fv < 0 {
+			return ruleMessage(message, CodeNonNegative, f.name, nil)
+		}
+
+		return nil
+	}
+
+	f.addValidation(validator, code)
+	return f
+}
+
+// MultipleOf checks if the field value is a multiple of step.
+// Floats are compared with a small tolerance, so that 0.3 is a multiple of 0.1.
+func (f *NumberField[T]) MultipleOf(step T, message ...string) *NumberField[T] {
+	code := CodeMultipleOf
+
+	validator := func(fv T) error {
+		if !isMultipleOf(fv, step) {
+			return ruleMessage(message, CodeMultipleOf, f.name, map[string]any{"step": step})
+		}
+
+		return nil
+	}
+
+	f.addValidation(validator, code)
+	return f
+}
+
+// Between checks if the field value lies between lo and hi.
+// bounds decides if lo and hi are themselves valid values.
+func (f *NumberField[T]) Between(lo, hi T, bounds Bounds, message ...string) *NumberField[T] {
+	code := CodeBetween
+
+	validator := func(fv T) error {
+		if !inBounds(fv, lo, hi, bounds) {
+			return ruleMessage(message, CodeBetween, f.name, map[string]any{"min": lo, "max": hi, "bounds": bounds, "actual": fv})
+		}
+
+		return nil
+	}
+
+	f.addValidation(validator, code)
+	return f
+}
+
+// IsOneOf checks if the field value is one of the values passed in the slice
+func (f *NumberField[T]) IsOneOf(values []T, message ...string) *NumberField[T] {
+	code := CodeIsOneOf
+
+	validator := func(fv T) error {
+		if !slices.Contains(values, fv) {
+			return ruleMessage(message, CodeIsOneOf, f.name, map[string]any{"allowed": values})
+		}
+
+		return nil
+	}
+
+	f.addValidation(validator, code)
+	return f
+}
+
+// Integer checks if the field value has no fractional part. Integer types always pass.
+func (f *NumberField[T]) Integer(message ...string) *NumberField[T] {
+	code := CodeInteger
+
+	validator := func(fv T) error {
+		if !isInteger(fv) {
+			return ruleMessage(message, CodeInteger, f.name, nil)
 		}
 
 		return nil
@@ -157,8 +249,88 @@ func (f *NumberField[T]) Max(value T, message ...string) *NumberField[T] {
 	return f
 }
 
+// Finite checks if the field value is neither NaN nor an infinity. Integer types always pass.
+func (f *NumberField[T]) Finite(message ...string) *NumberField[T] {
+	code := CodeFinite
+
+	validator := func(fv T) error {
+		if !isFinite(fv) {
+			return ruleMessage(message, CodeFinite, f.name, nil)
+		}
+
+		return nil
+	}
+
+	f.addValidation(validator, code)
+	return f
+}
+
+// Precision checks if the field value has atmost the provided number of decimal places
+func (f *NumberField[T]) Precision(decimals int, message ...string) *NumberField[T] {
+	code := CodePrecision
+
+	validator := func(fv T) error {
+		if !isFinite(fv) || decimalPlaces(fv) > decimals {
+			return ruleMessage(message, CodePrecision, f.name, map[string]any{"decimals": decimals})
+		}
+
+		return nil
+	}
+
+	f.addValidation(validator, code)
+	return f
+}
+
+// When adds the rules of 'then' to the field, but only applies them if 'cond' returns true when the field is parsed.
+// 'then' receives a new field with the same name to add the rules to.
+func (f *NumberField[T]) When(cond func() bool, then func(*NumberField[T])) *NumberField[T] {
+	sub := Number[T](nil, f.name)
+	then(sub)
+
+	f.dependent = true
+	f.addNested(func(ctx context.Context, value *T, errs *Collector) bool {
+		return !cond() || sub.ParseValueTo(ctx, value, errs)
+	})
+	return f
+}
+
+// Unless is like When, but applies the rules of 'then' only if 'cond' returns false
+func (f *NumberField[T]) Unless(cond func() bool, then func(*NumberField[T])) *NumberField[T] {
+	return f.When(func() bool { return !cond() }, then)
+}
+
+// RequiredIf makes the field required if 'cond' returns true when the field is parsed, and optional otherwise.
+// Nil and zero values count as missing, and the rules of a missing optional field are skipped.
+func (f *NumberField[T]) RequiredIf(cond func() bool) *NumberField[T] {
+	f.presence.requireIf(cond)
+	return f
+}
+
+// RequiredUnless makes the field required if 'cond' returns false, see RequiredIf
+func (f *NumberField[T]) RequiredUnless(cond func() bool) *NumberField[T] {
+	return f.RequiredIf(func() bool { return !cond() })
+}
+
+// RequiredWith makes the field required if any of the values pointed to by 'others' is present, see RequiredIf
+func (f *NumberField[T]) RequiredWith(others ...any) *NumberField[T] {
+	return f.RequiredIf(func() bool { return anyPresent(others) })
+}
+
+// ExcludedWith reports the field with CodeExcluded if it is present while any of the values pointed to by 'others' is.
+// The rules of the field are skipped while it is excluded.
+func (f *NumberField[T]) ExcludedWith(others ...any) *NumberField[T] {
+	f.presence.excludeIf(func() bool { return anyPresent(others) })
+	return f
+}
+
 // Refine lets you provide custom validation logic
 func (f *NumberField[T]) Refine(fn func(T) error, refinementData ...RefinementData) *NumberField[T] {
+	return f.RefineCtx(func(_ context.Context, value T) error { return fn(value) }, refinementData...)
+}
+
+// RefineCtx is like Refine, but 'fn' also receives the context passed to ParseCtx.
+// An error returned after the context is done is not reported as a refinement error.
+func (f *NumberField[T]) RefineCtx(fn func(context.Context, T) error, refinementData ...RefinementData) *NumberField[T] {
 	var newRefinementData RefinementData
 	if len(refinementData) > 0 {
 		newRefinementData = refinementData[0]
@@ -175,22 +347,124 @@ func (f *NumberField[T]) Transform(fn func(T) T) *NumberField[T] {
 }
 
 // Parse parses the field and returns a slice of Error.
-func (f *NumberField[T]) Parse() []Error {
-	var errs []Error
-	f._parse(&errs)
-	return errs
+func (f *NumberField[T]) Parse(opts ...ParseOption) []Error {
+	return f.ParseCtx(context.Background(), opts...)
+}
+
+// ParseCtx parses the field like Parse and passes 'ctx' to the refinements added with RefineCtx.
+// When 'ctx' is done the parse stops and a CodeCanceled or CodeDeadlineExceeded error is reported.
+func (f *NumberField[T]) ParseCtx(ctx context.Context, opts ...ParseOption) []Error {
+	errs := newCollector(opts)
+	f.ParseTo(ctx, &errs)
+	return parseResult(ctx, f.name, &errs, opts)
+}
+
+// ParseValue parses the provided value instead of the bound one and returns a slice of Error.
+func (f *NumberField[T]) ParseValue(value *T, opts ...ParseOption) []Error {
+	return f.ParseValueCtx(context.Background(), value, opts...)
+}
+
+// ParseValueCtx parses the provided value like ParseValue, with the context handling of ParseCtx.
+func (f *NumberField[T]) ParseValueCtx(ctx context.Context, value *T, opts ...ParseOption) []Error {
+	errs := newCollector(opts)
+	f.ParseValueTo(ctx, value, &errs)
+	return parseResult(ctx, f.name, &errs, opts)
+}
+
+// Validate parses the field and returns the errors as Errors, or nil if the field is valid.
+func (f *NumberField[T]) Validate(opts ...ParseOption) error {
+	return asError(f.Parse(opts...))
+}
+
+// ValidateValue parses the provided value like ParseValue and returns the errors as Errors, or nil if the value is valid.
+func (f *NumberField[T]) ValidateValue(value *T, opts ...ParseOption) error {
+	return asError(f.ParseValue(value, opts...))
 }
 
 // Number takes a pointer to a number and a variadic argument 'name'.
 // Even if multiple values are passed for 'name', only the first value will be considered.
+// Pass a nil pointer to declare a reusable schema and apply it with ParseValue.
 func Number[T number](value *T, name ...string) *NumberField[T] {
-	field := NumberField[T]{
-		value: value,
+	return &NumberField[T]{fieldCore: newFieldCore(value, name)}
+}
+
+func inBounds[T number](value, lo, hi T, bounds Bounds) bool {
+	aboveLo := value >= lo
+	if bounds == Exclusive || bounds == ExclusiveLo {
+		aboveLo = value > lo
+	}
+
+	belowHi := value <= hi
+	if bounds == Exclusive || bounds == ExclusiveHi {
+		belowHi = value < hi
+	}
+
+	return aboveLo && belowHi
+}
+
+func isFloat[T number]() bool {
+	var zero T
+	switch any(zero).(type) {
+	case float32, float64:
+		return true
+	}
+
+	return false
+}
+
+func isUnsigned[T number]() bool {
+	var zero T
+	switch any(zero).(type) {
+	case uint, uint8, uint16, uint32, uint64, uintptr:
+		return true
+	}
+
+	return false
+}
+
+func isMultipleOf[T number](value, step T) bool {
+	if step == 0 {
+		return value == 0
+	}
+
+	switch {
+	case isFloat[T]():
+		q := float64(value) / float64(step)
+		return math.Abs(q-math.Round(q)) <= 1e-9*math.Max(1, math.Abs(q))
+	case isUnsigned[T]():
+		return uint64(value)%uint64(step) == 0
+	default:
+		return int64(value)%int64(step) == 0
+	}
+}
+
+func isFinite[T number](value T) bool {
+	if !isFloat[T]() {
+		return true
+	}
+
+	return !math.IsNaN(float64(value)) && !math.IsInf(float64(value), 0)
+}
+
+func isInteger[T number](value T) bool {
+	return isFinite(value) && float64(value) == math.Trunc(float64(value))
+}
+
+func decimalPlaces[T number](value T) int {
+	if !isFloat[T]() {
+		return 0
+	}
+
+	var zero T
+	bits := 64
+	if _, ok := any(zero).(float32); ok {
+		bits = 32
 	}
 
-	if len(name) > 0 {
-		field.name = name[0]
+	s := strconv.FormatFloat(float64(value), 'f', -1, bits)
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		return len(s) - i - 1
 	}
 
-	return &field
+	return 0
 }
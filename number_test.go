@@ -2,6 +2,7 @@ package validator
 
 import (
 	"errors"
+	"math"
 	"testing"
 )
 
@@ -74,3 +75,211 @@ func TestNumberTransform(t *testing.T) {
 		t.Error("input not transformed properly")
 	}
 }
+
+func TestNumberTransformBeforeValidation(t *testing.T) {
+	input := 5
+
+	errs := Number(&input).Min(5).Transform(func(i int) int { return i * 2 }).Max(8).Parse()
+	if len(errs) == 0 {
+		t.Error("expected error")
+	}
+
+	input = 5
+	errs = Number(&input).Transform(func(i int) int { return i * 2 }).Min(10).Parse()
+	if len(errs) > 0 {
+		t.Error("expected no error")
+	}
+}
+
+func TestNumberOptionalNilPointer(t *testing.T) {
+	var input *float64
+
+	errs := Number(input).Optional().Min(5).Max(10).Parse()
+	if len(errs) > 0 {
+		t.Error("expected no error")
+	}
+}
+
+func TestNumberParseValue(t *testing.T) {
+	schema := Number[int](nil, "age").Min(18).Max(60)
+
+	for _, input := range []int{18, 30, 60} {
+		if errs := schema.ParseValue(&input); len(errs) > 0 {
+			t.Errorf("expected no error for %d", input)
+		}
+	}
+
+	for _, input := range []int{17, 61} {
+		if errs := schema.ParseValue(&input); len(errs) == 0 {
+			t.Errorf("expected error for %d", input)
+		}
+	}
+}
+
+func TestNumberUnsigned(t *testing.T) {
+	var zero uint
+	maxUint64 := uint64(math.MaxUint64)
+	maxUint8 := uint8(math.MaxUint8)
+	var errs []Error
+
+	errs = Number(&zero).Min(0).Max(10).Parse()
+	if len(errs) > 0 {
+		t.Error("expected no error")
+	}
+
+	errs = Number(&zero).Min(1).Parse()
+	if len(errs) == 0 {
+		t.Error("expected error")
+	}
+
+	errs = Number(&maxUint64).Min(math.MaxUint64).Parse()
+	if len(errs) > 0 {
+		t.Error("expected no error")
+	}
+
+	errs = Number(&maxUint64).Max(math.MaxUint64 - 1).Parse()
+	if len(errs) == 0 {
+		t.Error("expected error")
+	}
+
+	errs = Number(&maxUint8).Transform(func(i uint8) uint8 { return i - 1 }).Max(math.MaxUint8 - 1).Parse()
+	if len(errs) > 0 {
+		t.Error("expected no error")
+	}
+
+	ptr := uintptr(0)
+	errs = Number(&ptr).Max(0).Parse()
+	if len(errs) > 0 {
+		t.Error("expected no error")
+	}
+}
+
+func TestNumberSign(t *testing.T) {
+	positive, negative, zero := 3, -3, 0
+	var unsigned uint8
+
+	cases := []struct {
+		errs     []Error
+		expected bool
+	}{
+		{Number(&positive).Positive().Parse(), false},
+		{Number(&zero).Positive().Parse(), true},
+		{Number(&negative).Negative().Parse(), false},
+		{Number(&zero).Negative().Parse(), true},
+		{Number(&unsigned).Negative().Parse(), true},
+		{Number(&zero).NonNegative().Parse(), false},
+		{Number(&negative).NonNegative("must not be negative").Parse(), true},
+	}
+
+	for i, c := range cases {
+		if (len(c.errs) > 0) != c.expected {
+			t.Errorf("case %d: expected error to be %t, got %v", i, c.expected, c.errs)
+		}
+	}
+}
+
+func TestNumberMultipleOf(t *testing.T) {
+	goodInt, badInt := -9, 10
+	goodFloat, badFloat := 0.3, 0.35
+	bigUint := uint64(math.MaxUint64)
+
+	if errs := Number(&goodInt).MultipleOf(3).Parse(); len(errs) > 0 {
+		t.Error("expected no error")
+	}
+
+	if errs := Number(&badInt).MultipleOf(3).Parse(); len(errs) == 0 || errs[0].Code != CodeMultipleOf {
+		t.Error("expected multiple-of error")
+	}
+
+	if errs := Number(&goodFloat).MultipleOf(0.1).Parse(); len(errs) > 0 {
+		t.Error("expected no error")
+	}
+
+	if errs := Number(&badFloat).MultipleOf(0.1).Parse(); len(errs) == 0 {
+		t.Error("expected error")
+	}
+
+	if errs := Number(&bigUint).MultipleOf(5).Parse(); len(errs) > 0 {
+		t.Error("expected no error")
+	}
+}
+
+func TestNumberBetween(t *testing.T) {
+	cases := []struct {
+		value    int
+		bounds   Bounds
+		expected bool
+	}{
+		{1, Inclusive, false},
+		{5, Inclusive, false},
+		{0, Inclusive, true},
+		{1, Exclusive, true},
+		{5, Exclusive, true},
+		{3, Exclusive, false},
+		{1, ExclusiveLo, true},
+		{5, ExclusiveLo, false},
+		{1, ExclusiveHi, false},
+		{5, ExclusiveHi, true},
+	}
+
+	for _, c := range cases {
+		errs := Number(&c.value).Between(1, 5, c.bounds).Parse()
+		if (len(errs) > 0) != c.expected {
+			t.Errorf("%d with %s bounds: expected error to be %t", c.value, c.bounds, c.expected)
+		}
+	}
+}
+
+func TestNumberIsOneOf(t *testing.T) {
+	values := []uint16{80, 443}
+	goodInput := uint16(443)
+	badInput := uint16(8080)
+
+	if errs := Number(&goodInput).IsOneOf(values).Parse(); len(errs) > 0 {
+		t.Error("expected no error")
+	}
+
+	if errs := Number(&badInput).IsOneOf(values).Parse(); len(errs) == 0 || errs[0].Code != CodeIsOneOf {
+		t.Error("expected is-one-of error")
+	}
+}
+
+func TestNumberFloatRules(t *testing.T) {
+	cases := []struct {
+		value    float64
+		integer  bool
+		finite   bool
+		twoDigit bool
+	}{
+		{2, true, true, true},
+		{2.5, false, true, true},
+		{2.125, false, true, false},
+		{math.NaN(), false, false, false},
+		{math.Inf(1), false, false, false},
+		{math.Inf(-1), false, false, false},
+	}
+
+	for _, c := range cases {
+		if errs := Number(&c.value).Integer().Parse(); (len(errs) == 0) != c.integer {
+			t.Errorf("Integer(%v): expected %t", c.value, c.integer)
+		}
+
+		if errs := Number(&c.value).Finite().Parse(); (len(errs) == 0) != c.finite {
+			t.Errorf("Finite(%v): expected %t", c.value, c.finite)
+		}
+
+		if errs := Number(&c.value).Precision(2).Parse(); (len(errs) == 0) != c.twoDigit {
+			t.Errorf("Precision(%v): expected %t", c.value, c.twoDigit)
+		}
+	}
+
+	price := float32(19.99)
+	if errs := Number(&price).Precision(2).Parse(); len(errs) > 0 {
+		t.Error("expected no error")
+	}
+
+	count := 7
+	if errs := Number(&count).Integer().Finite().Precision(0).Parse(); len(errs) > 0 {
+		t.Error("expected no error")
+	}
+}
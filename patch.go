@@ -0,0 +1,54 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Patch holds a member of a JSON object, typically of a PATCH request, and tells its three states apart:
+// absent, null and present. A pointer can't, since encoding/json leaves it nil in both of the first two.
+// Bind a field to it with FromPatch.
+type Patch[T any] struct {
+	Value T
+	// Set tells if the member was in the object, including when it was null
+	Set bool
+	// Null tells if the member was null
+	Null bool
+}
+
+// UnmarshalJSON decodes the value of the member. encoding/json only calls it for members that are in the object.
+func (p *Patch[T]) UnmarshalJSON(data []byte) error {
+	var zero T
+	p.Value, p.Set, p.Null = zero, true, false
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		p.Null = true
+		return nil
+	}
+
+	return json.Unmarshal(data, &p.Value)
+}
+
+// MarshalJSON encodes the value, or null if the Patch is absent or null.
+func (p Patch[T]) MarshalJSON() ([]byte, error) {
+	if !p.Set || p.Null {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(p.Value)
+}
+
+type patchable[T any] interface {
+	bindPatch(p *Patch[T])
+}
+
+// FromPatch binds 'field', usually declared with a nil pointer, to the value of 'p' and returns it.
+//
+//   - absent: the value is missing. It gets the default of the field, or is reported as required unless the field is Optional.
+//   - null: the value is valid if the field is Nullable, and the rules are skipped. Otherwise it is missing, like an absent value.
+//   - present: the rules run on the value. With EmptyAsMissing, an empty value is missing, like an absent value.
+//
+// A default is stored in 'p', which is then present.
+func FromPatch[T any, F patchable[T]](p *Patch[T], field F) F {
+	field.bindPatch(p)
+	return field
+}
@@ -0,0 +1,110 @@
+package validator
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type updateUser struct {
+	Name     Patch[string] `json:"name"`
+	Nickname Patch[string] `json:"nickname"`
+	Age      Patch[int]    `json:"age"`
+}
+
+func TestPatchUnmarshal(t *testing.T) {
+	var req updateUser
+	if err := json.Unmarshal([]byte(`{"name": "aaditya", "nickname": null}`), &req); err != nil {
+		t.Fatal(err)
+	}
+
+	if !req.Name.Set || req.Name.Null || req.Name.Value != "aaditya" {
+		t.Errorf("expected name to be present, got %+v", req.Name)
+	}
+
+	if !req.Nickname.Set || !req.Nickname.Null {
+		t.Errorf("expected nickname to be null, got %+v", req.Nickname)
+	}
+
+	if req.Age.Set {
+		t.Errorf("expected age to be absent, got %+v", req.Age)
+	}
+
+	data, err := json.Marshal(req)
+	if err != nil || string(data) != `{"name":"aaditya","nickname":null,"age":null}` {
+		t.Errorf("unexpected json %s %v", data, err)
+	}
+}
+
+func TestFromPatch(t *testing.T) {
+	var req updateUser
+	json.Unmarshal([]byte(`{"name": "aa", "nickname": null}`), &req)
+
+	errs := Struct(&req, "user").Fields(
+		FromPatch(&req.Name, String(nil, "name").Optional().Min(3)),
+		FromPatch(&req.Nickname, String(nil, "nickname").Nullable().Min(3)),
+		FromPatch(&req.Age, Number[int](nil, "age").Default(18).Min(18)),
+	).Parse()
+
+	if len(errs) != 1 || errs[0].Field != "user.name" || errs[0].Code != CodeMin {
+		t.Errorf("expected a min error for name, got %v", errs)
+	}
+
+	if !req.Age.Set || req.Age.Value != 18 {
+		t.Errorf("expected age to get the default, got %+v", req.Age)
+	}
+
+	req = updateUser{}
+	json.Unmarshal([]byte(`{"nickname": null}`), &req)
+
+	errs = Struct(&req).Fields(
+		FromPatch(&req.Name, String(nil, "name").Nullable()),
+		FromPatch(&req.Nickname, String(nil, "nickname").Optional()),
+	).Parse()
+
+	if len(errs) != 1 || errs[0].Field != "name" || errs[0].Code != CodeRequired {
+		t.Errorf("expected absent name to be required although it is nullable, got %v", errs)
+	}
+}
+
+func TestEmptyAsMissing(t *testing.T) {
+	var role, nickname, bio string
+	count := 0
+
+	errs := Struct[struct{}](&struct{}{}).Fields(
+		String(&role, "role").EmptyAsMissing().Default("member").IsOneOf([]string{"admin", "member"}),
+		String(&nickname, "nickname").EmptyAsMissing().Optional().Min(3),
+		String(&bio, "bio").EmptyAsMissing(),
+		Number(&count, "count").Default(1).Min(1),
+	).Parse()
+
+	if role != "member" {
+		t.Errorf("expected the default role, got %q", role)
+	}
+
+	if len(errs) != 2 || errs[0].Field != "bio" || errs[0].Code != CodeRequired || errs[1].Field != "count" {
+		t.Errorf("expected bio to be required and the zero count to be kept, got %v", errs)
+	}
+}
+
+func TestDefaultNilPointer(t *testing.T) {
+	errs := String(nil, "role").Default("member").Parse()
+	if len(errs) != 1 || errs[0].Code != CodeRequired {
+		t.Errorf("expected a nil pointer to be required, got %v", errs)
+	}
+
+	if errs := String(nil, "role").Default("member").Optional().Parse(); len(errs) > 0 {
+		t.Errorf("expected an optional nil pointer to be valid, got %v", errs)
+	}
+}
+
+func TestNullable(t *testing.T) {
+	var tags *[]string
+
+	if errs := Slice(tags, "tags").Nullable().Min(1).Parse(); len(errs) > 0 {
+		t.Errorf("expected nil to be accepted, got %v", errs)
+	}
+
+	if errs := Slice(tags, "tags").Min(1).Parse(); len(errs) != 1 || errs[0].Code != CodeRequired {
+		t.Errorf("expected nil to be required, got %v", errs)
+	}
+}
@@ -0,0 +1,182 @@
+// Package problem renders validation errors as RFC 7807 problem details documents
+// (application/problem+json) and decodes them back into validation errors.
+//
+// The errors are listed in the "invalid-params" extension member:
+//
+//	{
+//	  "type": "about:blank",
+//	  "title": "Unprocessable Entity",
+//	  "status": 422,
+//	  "invalid-params": [
+//	    {"name": "user.email", "code": "email", "reason": "email is not a valid email"}
+//	  ]
+//	}
+package problem
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/aaditya-23/validator"
+)
+
+// ContentType is the media type of problem details documents
+const ContentType = "application/problem+json"
+
+// InvalidParam describes a failed validation rule in the "invalid-params" extension.
+// Name is the path to the field, as in validator.Error.Field, and Params the params of the rule.
+type InvalidParam struct {
+	Name   string         `json:"name"`
+	Code   string         `json:"code"`
+	Reason string         `json:"reason"`
+	Type   string         `json:"type,omitempty"`
+	Params map[string]any `json:"params,omitempty"`
+}
+
+// Details is a problem details document.
+// Members are encoded in the order they are declared, so documents are stable.
+type Details struct {
+	Type          string         `json:"type"`
+	Title         string         `json:"title"`
+	Status        int            `json:"status,omitempty"`
+	Detail        string         `json:"detail,omitempty"`
+	Instance      string         `json:"instance,omitempty"`
+	InvalidParams []InvalidParam `json:"invalid-params"`
+}
+
+// Renderer turns validation errors into problem details documents. The zero value is ready to use.
+type Renderer struct {
+	// Type is the type URI of the documents. It defaults to "about:blank".
+	Type string
+	// Title is the title of the documents. It defaults to the status text of the response.
+	Title string
+	// Detail is an optional explanation added to every document.
+	Detail string
+	// Types maps the codes of the validator package, such as validator.CodeEmail,
+	// to the type URIs reported for invalid params with that code.
+	Types map[string]string
+}
+
+var defaultRenderer = &Renderer{}
+
+// Problem builds the document for a response with the provided status
+func (rd *Renderer) Problem(status int, errs []validator.Error) Details {
+	d := Details{
+		Type:          rd.Type,
+		Title:         rd.Title,
+		Status:        status,
+		Detail:        rd.Detail,
+		InvalidParams: make([]InvalidParam, 0, len(errs)),
+	}
+
+	if d.Type == "" {
+		d.Type = "about:blank"
+	}
+	if d.Title == "" {
+		d.Title = http.StatusText(status)
+	}
+
+	for _, e := range errs {
+		d.InvalidParams = append(d.InvalidParams, InvalidParam{Name: e.Field, Code: e.Code, Reason: e.Message, Type: rd.Types[e.Code], Params: e.Params})
+	}
+
+	return d
+}
+
+// Render writes the document as the response. Its signature matches httpbind.Renderer,
+// so it can be used to render the errors of the httpbind package.
+func (rd *Renderer) Render(w http.ResponseWriter, r *http.Request, status int, errs []validator.Error) {
+	d := rd.Problem(status, errs)
+	if r != nil && r.URL != nil {
+		d.Instance = r.URL.Path
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(d)
+}
+
+// Render writes the document as the response with the default Renderer
+func Render(w http.ResponseWriter, r *http.Request, status int, errs []validator.Error) {
+	defaultRenderer.Render(w, r, status, errs)
+}
+
+// Errors rebuilds the validation errors listed in the document, including their paths.
+func (d Details) Errors() []validator.Error {
+	errs := make([]validator.Error, 0, len(d.InvalidParams))
+	for _, p := range d.InvalidParams {
+		errs = append(errs, validator.Error{Field: p.Name, Message: p.Reason, Code: p.Code, Path: parsePath(p.Name), Params: p.Params})
+	}
+
+	return errs
+}
+
+// Decode reads a problem details document and returns the validation errors it lists.
+func Decode(r io.Reader) ([]validator.Error, error) {
+	var d Details
+	if err := json.NewDecoder(r).Decode(&d); err != nil {
+		return nil, fmt.Errorf("problem: decoding document: %w", err)
+	}
+
+	return d.Errors(), nil
+}
+
+// parsePath is the reverse of the formatting of validator.Error.Field.
+// Numbers in brackets are read as slice indexes and quoted strings as map keys.
+func parsePath(s string) []validator.PathSegment {
+	var path []validator.PathSegment
+	for s != "" {
+		switch s[0] {
+		case '.':
+			s = s[1:]
+		case '[':
+			end := closingBracket(s)
+			if end < 0 {
+				return append(path, validator.PathSegment{Kind: validator.SegmentField, Name: s})
+			}
+
+			inner := s[1:end]
+			if key, err := strconv.Unquote(inner); err == nil {
+				path = append(path, validator.PathSegment{Kind: validator.SegmentKey, Key: key})
+			} else if index, err := strconv.Atoi(inner); err == nil {
+				path = append(path, validator.PathSegment{Kind: validator.SegmentIndex, Index: index})
+			} else {
+				path = append(path, validator.PathSegment{Kind: validator.SegmentKey, Key: inner})
+			}
+			s = s[end+1:]
+		default:
+			end := strings.IndexAny(s, ".[")
+			if end < 0 {
+				end = len(s)
+			}
+			path = append(path, validator.PathSegment{Kind: validator.SegmentField, Name: s[:end]})
+			s = s[end:]
+		}
+	}
+
+	return path
+}
+
+// closingBracket returns the index of the bracket closing the one at the start of s, skipping quoted keys.
+func closingBracket(s string) int {
+	if len(s) > 1 && s[1] == '"' {
+		for i := 2; i < len(s); i++ {
+			switch s[i] {
+			case '\\':
+				i++
+			case '"':
+				if i+1 < len(s) && s[i+1] == ']' {
+					return i + 1
+				}
+				return -1
+			}
+		}
+		return -1
+	}
+
+	return strings.IndexByte(s, ']')
+}
@@ -0,0 +1,85 @@
+package problem
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/aaditya-23/validator"
+)
+
+func TestRender(t *testing.T) {
+
+	type item struct {
+		Name string
+	}
+
+	type order struct {
+		Email string
+		Items []item
+	}
+
+	schema := validator.Struct[order](nil).
+		Members(
+			validator.Member(func(o *order) *string { return &o.Email }, validator.String(nil, "email").Email()),
+			validator.Member(func(o *order) *[]item { return &o.Items }, validator.Slice[item](nil, "items").Each(
+				validator.Struct[item](nil).Members(
+					validator.Member(func(i *item) *string { return &i.Name }, validator.String(nil, "name").Min(3)),
+				),
+			)),
+		)
+
+	errs := schema.ParseValue(&order{Email: "aaditya", Items: []item{{Name: "pen"}, {Name: "ab"}}})
+
+	rd := &Renderer{Types: map[string]string{validator.CodeEmail: "https://example.com/probs/email"}}
+	w := httptest.NewRecorder()
+	rd.Render(w, httptest.NewRequest(http.MethodPost, "/orders", nil), http.StatusUnprocessableEntity, errs)
+
+	if ct := w.Header().Get("Content-Type"); ct != ContentType {
+		t.Errorf("unexpected content type %q", ct)
+	}
+
+	expected := `{"type":"about:blank","title":"Unprocessable Entity","status":422,"instance":"/orders","invalid-params":[` +
+		`{"name":"email","code":"email","reason":"email is not a valid email","type":"https://example.com/probs/email"},` +
+		`{"name":"items[1].name","code":"min","reason":"name should have atleast 3 characters","params":{"actual":2,"min":3}}]}` + "\n"
+
+	if w.Body.String() != expected {
+		t.Errorf("unexpected document:\n%s\nexpected:\n%s", w.Body.String(), expected)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	errs := []validator.Error{
+		{Field: "user.tags[2]", Message: "tag is too long", Code: validator.CodeMax, Path: []validator.PathSegment{
+			{Kind: validator.SegmentField, Name: "user"},
+			{Kind: validator.SegmentField, Name: "tags"},
+			{Kind: validator.SegmentIndex, Index: 2},
+		}},
+		{Field: `labels["a.b]"].value`, Message: "value is required", Code: validator.CodeRequired, Path: []validator.PathSegment{
+			{Kind: validator.SegmentField, Name: "labels"},
+			{Kind: validator.SegmentKey, Key: "a.b]"},
+			{Kind: validator.SegmentField, Name: "value"},
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode((&Renderer{}).Problem(http.StatusBadRequest, errs)); err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(decoded, errs) {
+		t.Errorf("expected %+v, got %+v", errs, decoded)
+	}
+
+	if _, err := Decode(bytes.NewBufferString(`{"invalid-params":`)); err == nil {
+		t.Error("expected error for malformed document")
+	}
+}
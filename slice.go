@@ -1,90 +1,50 @@
 package validator
 
 import (
-	"errors"
-	"fmt"
+	"context"
+	"slices"
 )
 
-type sliceAction[T any] struct {
-	validator      func() error
-	refinement     func([]T) error
-	transformer    func([]T) []T
-	code           string
-	refinementData RefinementData
-}
-
 type SliceField[T any] struct {
-	value         *[]T
-	name          string
-	optional      bool
-	requiredError string
-	actions       []sliceAction[T]
-	abortEarly    bool
+	fieldCore[[]T]
 }
 
-func (f *SliceField[T]) addValidation(fn func() error, code string) {
-	r := sliceAction[T]{validator: fn, code: code}
-	f.actions = append(f.actions, r)
-}
+func (f *SliceField[T]) ParseTo(ctx context.Context, errs *Collector) bool {
+	value, ok, done := f.boundValue(errs)
+	if done {
+		return ok
+	}
 
-func (f *SliceField[T]) addRefinement(fn func([]T) error, refinementData RefinementData) {
-	r := sliceAction[T]{refinement: fn, refinementData: refinementData}
-	f.actions = append(f.actions, r)
+	return f.ParseValueTo(ctx, value, errs)
 }
 
-func (f *SliceField[T]) addTransformer(fn func([]T) []T) {
-	r := sliceAction[T]{transformer: fn}
-	f.actions = append(f.actions, r)
+func (f *SliceField[T]) ParseValueTo(ctx context.Context, value *[]T, errs *Collector) bool {
+	return f.parse(ctx, value, errs)
 }
 
-func (f *SliceField[T]) _parse(errs *[]Error) bool {
-	if f.value == nil {
-		if !f.optional {
-			*errs = append(*errs, requiredFieldErr(f.name, f.requiredError))
+func (f *SliceField[T]) parseEach(ctx context.Context, items []T, schema Schema[T], errs *Collector) bool {
+	ok := true
+	for i := range items {
+		if errs.Done(ctx) {
 			return false
 		}
 
-		return true
-	}
-
-	isFieldParsedSuccessfully := true
-	for _, action := range f.actions {
-		isActionParsedSuccessfully := true
+		start := errs.Len()
+		itemOk := schema.ParseValueTo(ctx, &items[i], errs)
 
-		if action.validator != nil {
-			err := action.validator()
-			if err != nil {
-				isActionParsedSuccessfully = false
-				*errs = append(*errs, Error{Field: f.name, Message: err.Error(), Code: action.code})
-			}
-		} else if action.refinement != nil {
-			err := action.refinement(*f.value)
-			if err != nil {
-				isActionParsedSuccessfully = false
-				me := Error{Field: f.name, Message: err.Error(), Code: CodeRefinement}
-				if action.refinementData.Field != "" {
-					me.Field = action.refinementData.Field
-				}
-				if action.refinementData.Code != "" {
-					me.Code = action.refinementData.Code
-				}
-
-				*errs = append(*errs, me)
-			}
-		} else if action.transformer != nil {
-			*f.value = action.transformer(*f.value)
-			continue
-		}
+		path := append(fieldPath(f.name), PathSegment{Kind: SegmentIndex, Index: i})
+		nameErrors(errs.Errors()[start:], formatPath(path))
+		errs.Prefix(start, path...)
 
-		if !isActionParsedSuccessfully {
-			isFieldParsedSuccessfully = false
+		if !itemOk {
+			ok = false
 			if f.abortEarly {
 				return false
 			}
 		}
 	}
 
-	return isFieldParsedSuccessfully
+	return ok
 }
 
 // AbortEarly stops the parsing of the field on the first error
@@ -105,21 +65,40 @@ func (f *SliceField[T]) RequiredError(message string) *SliceField[T] {
 	return f
 }
 
+// Default sets the value of the field to 'value' when it is missing, before the rules run.
+// A value is missing when its Patch is absent or null, or when it is empty with EmptyAsMissing, see FromPatch.
+// A nil pointer can't hold the default, so it is still required unless the field is Optional.
+func (f *SliceField[T]) Default(value []T) *SliceField[T] {
+	return f.DefaultFunc(func() []T { return value })
+}
+
+// DefaultFunc is like Default, but calls 'fn' for the default every time the value is missing.
+func (f *SliceField[T]) DefaultFunc(fn func() []T) *SliceField[T] {
+	f.defaultValue = fn
+	return f
+}
+
+// Nullable accepts null values, a nil pointer or a null Patch, and skips the rules for them.
+// Without it, null counts as missing.
+func (f *SliceField[T]) Nullable() *SliceField[T] {
+	f.nullable = true
+	return f
+}
+
+// EmptyAsMissing treats the zero value of the field, or an empty slice or map, as missing.
+// It then gets the default, or is reported as required unless the field is Optional.
+func (f *SliceField[T]) EmptyAsMissing() *SliceField[T] {
+	f.emptyAsMissing = true
+	return f
+}
+
 // Min sets the minimum length of the slice
 func (f *SliceField[T]) Min(length int, message ...string) *SliceField[T] {
-	fv := *f.value
 	code := CodeMin
 
-	validator := func() error {
+	validator := func(fv []T) error {
 		if len(fv) < length {
-			var msg string
-			if len(message) > 0 {
-				msg = message[0]
-			} else {
-				msg = fmt.Sprintf("%s must have atleast %d items", f.name, length)
-			}
-
-			return errors.New(msg)
+			return ruleMessage(message, CodeMin+".slice", f.name, map[string]any{"min": length, "actual": len(fv)})
 		}
 		return nil
 	}
@@ -130,52 +109,93 @@ func (f *SliceField[T]) Min(length int, message ...string) *SliceField[T] {
 
 // Max sets the maximum length of the slice
 func (f *SliceField[T]) Max(length int, message ...string) *SliceField[T] {
-	fv := *f.value
-	rule := "max"
+	code := CodeMax
 
-	validator := func() error {
+	validator := func(fv []T) error {
 		if len(fv) > length {
-			var msg string
-			if len(message) > 0 {
-				msg = message[0]
-			} else {
-				msg = fmt.Sprintf("%s must have atmost %d items", f.name, length)
-			}
-
-			return errors.New(msg)
+			return ruleMessage(message, CodeMax+".slice", f.name, map[string]any{"max": length, "actual": len(fv)})
 		}
 		return nil
 	}
 
-	f.addValidation(validator, rule)
+	f.addValidation(validator, code)
 	return f
 }
 
 // Length checks if the slice has exactly the provided length
 func (f *SliceField[T]) Length(value int, message ...string) *SliceField[T] {
-	fv := *f.value
-	rule := "length"
+	code := CodeLength
 
-	validator := func() error {
+	validator := func(fv []T) error {
 		if len(fv) != value {
-			var msg string
-			if len(message) > 0 {
-				msg = message[0]
-			} else {
-				msg = fmt.Sprintf("%s must have %d items", f.name, value)
-			}
-
-			return errors.New(msg)
+			return ruleMessage(message, CodeLength+".slice", f.name, map[string]any{"length": value, "actual": len(fv)})
 		}
 		return nil
 	}
 
-	f.addValidation(validator, rule)
+	f.addValidation(validator, code)
+	return f
+}
+
+// Each parses every item of the slice with the provided schema.
+// Errors of an item are reported with its index, for example 'tags[3]'.
+func (f *SliceField[T]) Each(schema Schema[T]) *SliceField[T] {
+	f.addNested(func(ctx context.Context, value *[]T, errs *Collector) bool {
+		return f.parseEach(ctx, *value, schema, errs)
+	})
+	return f
+}
+
+// When adds the rules of 'then' to the field, but only applies them if 'cond' returns true when the field is parsed.
+// 'then' receives a new field with the same name to add the rules to.
+func (f *SliceField[T]) When(cond func() bool, then func(*SliceField[T])) *SliceField[T] {
+	sub := Slice[T](nil, f.name)
+	then(sub)
+
+	f.dependent = true
+	f.addNested(func(ctx context.Context, value *[]T, errs *Collector) bool {
+		return !cond() || sub.ParseValueTo(ctx, value, errs)
+	})
+	return f
+}
+
+// Unless is like When, but applies the rules of 'then' only if 'cond' returns false
+func (f *SliceField[T]) Unless(cond func() bool, then func(*SliceField[T])) *SliceField[T] {
+	return f.When(func() bool { return !cond() }, then)
+}
+
+// RequiredIf makes the field required if 'cond' returns true when the field is parsed, and optional otherwise.
+// Nil and zero values count as missing, and the rules of a missing optional field are skipped.
+func (f *SliceField[T]) RequiredIf(cond func() bool) *SliceField[T] {
+	f.presence.requireIf(cond)
+	return f
+}
+
+// RequiredUnless makes the field required if 'cond' returns false, see RequiredIf
+func (f *SliceField[T]) RequiredUnless(cond func() bool) *SliceField[T] {
+	return f.RequiredIf(func() bool { return !cond() })
+}
+
+// RequiredWith makes the field required if any of the values pointed to by 'others' is present, see RequiredIf
+func (f *SliceField[T]) RequiredWith(others ...any) *SliceField[T] {
+	return f.RequiredIf(func() bool { return anyPresent(others) })
+}
+
+// ExcludedWith reports the field with CodeExcluded if it is present while any of the values pointed to by 'others' is.
+// The rules of the field are skipped while it is excluded.
+func (f *SliceField[T]) ExcludedWith(others ...any) *SliceField[T] {
+	f.presence.excludeIf(func() bool { return anyPresent(others) })
 	return f
 }
 
 // Refine lets you provide custom validation logic
 func (f *SliceField[T]) Refine(fn func([]T) error, refinementData ...RefinementData) *SliceField[T] {
+	return f.RefineCtx(func(_ context.Context, value []T) error { return fn(value) }, refinementData...)
+}
+
+// RefineCtx is like Refine, but 'fn' also receives the context passed to ParseCtx.
+// An error returned after the context is done is not reported as a refinement error.
+func (f *SliceField[T]) RefineCtx(fn func(context.Context, []T) error, refinementData ...RefinementData) *SliceField[T] {
 	var newRefinementData RefinementData
 	if len(refinementData) > 0 {
 		newRefinementData = refinementData[0]
@@ -192,22 +212,45 @@ func (f *SliceField[T]) Transform(fn func([]T) []T) *SliceField[T] {
 }
 
 // Parse parses the field and returns a slice of Error.
-func (f *SliceField[T]) Parse() []Error {
-	var errs []Error
-	f._parse(&errs)
-	return errs
+func (f *SliceField[T]) Parse(opts ...ParseOption) []Error {
+	return f.ParseCtx(context.Background(), opts...)
+}
+
+// ParseCtx parses the field like Parse and passes 'ctx' to the refinements added with RefineCtx.
+// When 'ctx' is done the parse stops and a CodeCanceled or CodeDeadlineExceeded error is reported.
+func (f *SliceField[T]) ParseCtx(ctx context.Context, opts ...ParseOption) []Error {
+	errs := newCollector(opts)
+	f.ParseTo(ctx, &errs)
+	return parseResult(ctx, f.name, &errs, opts)
+}
+
+// ParseValue parses the provided value instead of the bound one and returns a slice of Error.
+func (f *SliceField[T]) ParseValue(value *[]T, opts ...ParseOption) []Error {
+	return f.ParseValueCtx(context.Background(), value, opts...)
+}
+
+// ParseValueCtx parses the provided value like ParseValue, with the context handling of ParseCtx.
+func (f *SliceField[T]) ParseValueCtx(ctx context.Context, value *[]T, opts ...ParseOption) []Error {
+	errs := newCollector(opts)
+	f.ParseValueTo(ctx, value, &errs)
+	return parseResult(ctx, f.name, &errs, opts)
+}
+
+// Validate parses the field and returns the errors as Errors, or nil if the field is valid.
+func (f *SliceField[T]) Validate(opts ...ParseOption) error {
+	return asError(f.Parse(opts...))
+}
+
+// ValidateValue parses the provided value like ParseValue and returns the errors as Errors, or nil if the value is valid.
+func (f *SliceField[T]) ValidateValue(value *[]T, opts ...ParseOption) error {
+	return asError(f.ParseValue(value, opts...))
 }
 
 // Slice takes a pointer to a slice and a variadic argument 'name'.
 // Even if multiple values are passed for 'name', only the first value will be considered.
+// Pass a nil pointer to declare a reusable schema and apply it with ParseValue.
 func Slice[T any](value *[]T, name ...string) *SliceField[T] {
-	field := SliceField[T]{
-		value: value,
-	}
-
-	if len(name) > 0 {
-		field.name = name[0]
-	}
-
-	return &field
+	f := &SliceField[T]{fieldCore: newFieldCore(value, name)}
+	f.clone = slices.Clone[[]T]
+	return f
 }
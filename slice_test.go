@@ -34,8 +34,8 @@ func TestSliceMax(t *testing.T) {
 	}
 
 	errs = Slice(&badInput).Max(value).Parse()
-	if len(errs) == 0 {
-		t.Error("expected error")
+	if len(errs) == 0 || errs[0].Code != CodeMax {
+		t.Errorf("expected max error, got %v", errs)
 	}
 }
 
@@ -51,8 +51,8 @@ func TestSliceLength(t *testing.T) {
 	}
 
 	errs = Slice(&badInput).Length(value).Parse()
-	if len(errs) == 0 {
-		t.Error("expected error")
+	if len(errs) == 0 || errs[0].Code != CodeLength {
+		t.Errorf("expected length error, got %v", errs)
 	}
 }
 
@@ -103,3 +103,77 @@ func TestSliceTransform(t *testing.T) {
 	}
 
 }
+
+func TestSliceTransformBeforeValidation(t *testing.T) {
+	input := []int{1, 2}
+
+	errs := Slice(&input).
+		Transform(func(v []int) []int {
+			return append(v, 3, 4)
+		}).
+		Min(3).
+		Length(4).
+		Parse()
+
+	if len(errs) > 0 {
+		t.Error("expected no error")
+	}
+}
+
+func TestSliceOptionalNilPointer(t *testing.T) {
+	var input *[]string
+
+	errs := Slice(input).Optional().Min(1).Max(3).Parse()
+	if len(errs) > 0 {
+		t.Error("expected no error")
+	}
+}
+
+func TestSliceEach(t *testing.T) {
+	goodInput := []string{" go ", "rust"}
+	badInput := []string{"go", "c", "zig", "d"}
+
+	errs := Slice(&goodInput, "tags").Each(String(nil).TrimSpace().Min(2)).Parse()
+	if len(errs) > 0 {
+		t.Error("expected no error")
+	}
+
+	if goodInput[0] != "go" {
+		t.Error("item not transformed properly")
+	}
+
+	errs = Slice(&badInput, "tags").Each(String(nil).Min(2)).Parse()
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %d", len(errs))
+	}
+
+	if errs[0].Field != "tags[1]" || errs[1].Field != "tags[3]" {
+		t.Errorf("unexpected fields %q and %q", errs[0].Field, errs[1].Field)
+	}
+
+	if errs[0].Message != "tags[1] should have atleast 2 characters" {
+		t.Errorf("expected the unnamed item to be named by its index, got %q", errs[0].Message)
+	}
+
+	errs = Slice(&badInput, "tags").Each(String(nil).Min(2)).AbortEarly().Parse()
+	if len(errs) != 1 {
+		t.Errorf("expected 1 error, got %d", len(errs))
+	}
+}
+
+func TestSliceEachStruct(t *testing.T) {
+
+	type User struct {
+		name string
+	}
+
+	input := []User{{name: "aaditya"}, {name: "aadi@23"}}
+
+	errs := Slice(&input, "users").
+		Each(Struct[User](nil).Members(Member(func(u *User) *string { return &u.name }, String(nil, "name").Alpha()))).
+		Parse()
+
+	if len(errs) != 1 || errs[0].Field != "users[1].name" {
+		t.Errorf("expected error for users[1].name, got %v", errs)
+	}
+}
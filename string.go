@@ -1,91 +1,25 @@
 package validator
 
 import (
-	"errors"
-	"fmt"
+	"context"
 	"strings"
 )
 
-type stringAction struct {
-	validator      func() error
-	refinement     func(string) error
-	transformer    func(string) string
-	code           string
-	refinementData RefinementData
-}
-
 type StringField struct {
-	value         *string
-	name          string
-	optional      bool
-	requiredError string
-	actions       []stringAction
-	abortEarly    bool
-}
-
-func (f *StringField) addValidation(fn func() error, code string) {
-	action := stringAction{validator: fn, code: code}
-	f.actions = append(f.actions, action)
-}
-
-func (f *StringField) addRefinement(fn func(string) error, refinementData RefinementData) {
-	action := stringAction{refinement: fn, refinementData: refinementData}
-	f.actions = append(f.actions, action)
+	fieldCore[string]
 }
 
-func (f *StringField) addTransformer(fn func(string) string) {
-	action := stringAction{transformer: fn}
-	f.actions = append(f.actions, action)
-}
-
-func (f *StringField) _parse(errs *[]Error) bool {
-	if f.value == nil {
-		if !f.optional {
-			*errs = append(*errs, requiredFieldErr(f.name, f.requiredError))
-			return false
-		}
-
-		return true
+func (f *StringField) ParseTo(ctx context.Context, errs *Collector) bool {
+	value, ok, done := f.boundValue(errs)
+	if done {
+		return ok
 	}
 
-	isFieldParsedSuccessfully := true
-	for _, action := range f.actions {
-		isActionParsedSuccessfully := true
-
-		if action.validator != nil {
-			err := action.validator()
-			if err != nil {
-				isActionParsedSuccessfully = false
-				*errs = append(*errs, Error{Field: f.name, Message: err.Error(), Code: action.code})
-			}
-		} else if action.refinement != nil {
-			err := action.refinement(*f.value)
-			if err != nil {
-				isActionParsedSuccessfully = false
-				me := Error{Field: f.name, Message: err.Error(), Code: CodeRefinement}
-				if action.refinementData.Field != "" {
-					me.Field = action.refinementData.Field
-				}
-				if action.refinementData.Code != "" {
-					me.Code = action.refinementData.Code
-				}
-
-				*errs = append(*errs, me)
-			}
-		} else if action.transformer != nil {
-			*f.value = action.transformer(*f.value)
-			continue
-		}
-
-		if !isActionParsedSuccessfully {
-			isFieldParsedSuccessfully = false
-			if f.abortEarly {
-				return false
-			}
-		}
-	}
+	return f.ParseValueTo(ctx, value, errs)
+}
 
-	return isFieldParsedSuccessfully
+func (f *StringField) ParseValueTo(ctx context.Context, value *string, errs *Collector) bool {
+	return f.parse(ctx, value, errs)
 }
 
 // AbortEarly stops the parsing of the field on the first error
@@ -106,21 +40,40 @@ func (f *StringField) RequiredError(message string) *StringField {
 	return f
 }
 
+// Default sets the value of the field to 'value' when it is missing, before the rules run.
+// A value is missing when its Patch is absent or null, or when it is empty with EmptyAsMissing, see FromPatch.
+// A nil pointer can't hold the default, so it is still required unless the field is Optional.
+func (f *StringField) Default(value string) *StringField {
+	return f.DefaultFunc(func() string { return value })
+}
+
+// DefaultFunc is like Default, but calls 'fn' for the default every time the value is missing.
+func (f *StringField) DefaultFunc(fn func() string) *StringField {
+	f.defaultValue = fn
+	return f
+}
+
+// Nullable accepts null values, a nil pointer or a null Patch, and skips the rules for them.
+// Without it, null counts as missing.
+func (f *StringField) Nullable() *StringField {
+	f.nullable = true
+	return f
+}
+
+// EmptyAsMissing treats the zero value of the field, or an empty slice or map, as missing.
+// It then gets the default, or is reported as required unless the field is Optional.
+func (f *StringField) EmptyAsMissing() *StringField {
+	f.emptyAsMissing = true
+	return f
+}
+
 // Min checks if the field value has the provided minimum length
 func (f *StringField) Min(length int, message ...string) *StringField {
-	fv := *f.value
 	code := CodeMin
 
-	validator := func() error {
+	validator := func(fv string) error {
 		if len(fv) < length {
-			var msg string
-			if len(message) > 0 {
-				msg = message[0]
-			} else {
-				msg = fmt.Sprintf("%s should have atleast %d characters", f.name, length)
-			}
-
-			return errors.New(msg)
+			return ruleMessage(message, CodeMin+".string", f.name, map[string]any{"min": length, "actual": len(fv)})
 		}
 
 		return nil
@@ -132,19 +85,11 @@ func (f *StringField) Min(length int, message ...string) *StringField {
 
 // Max checks if the field value has the provided maximum length
 func (f *StringField) Max(length int, message ...string) *StringField {
-	fv := *f.value
 	code := CodeMax
 
-	validator := func() error {
+	validator := func(fv string) error {
 		if len(fv) > length {
-			var msg string
-			if len(message) > 0 {
-				msg = message[0]
-			} else {
-				msg = fmt.Sprintf("%s can have atmost %d characters", f.name, length)
-			}
-
-			return errors.New(msg)
+			return ruleMessage(message, CodeMax+".string", f.name, map[string]any{"max": length, "actual": len(fv)})
 		}
 
 		return nil
@@ -156,19 +101,11 @@ func (f *StringField) Max(length int, message ...string) *StringField {
 
 // Length checks if the field value has the provided length
 func (f *StringField) Length(value int, message ...string) *StringField {
-	fv := *f.value
 	code := CodeLength
 
-	validator := func() error {
+	validator := func(fv string) error {
 		if len(fv) != value {
-			var msg string
-			if len(message) > 0 {
-				msg = message[0]
-			} else {
-				msg = fmt.Sprintf("%s should have %d characters", f.name, value)
-			}
-
-			return errors.New(msg)
+			return ruleMessage(message, CodeLength+".string", f.name, map[string]any{"length": value, "actual": len(fv)})
 		}
 
 		return nil
@@ -180,19 +117,11 @@ func (f *StringField) Length(value int, message ...string) *StringField {
 
 // Contains checks if the field value contains the provided substring
 func (f *StringField) Contains(substr string, message ...string) *StringField {
-	fv := *f.value
 	code := CodeContains
 
-	validator := func() error {
+	validator := func(fv string) error {
 		if !strings.Contains(fv, substr) {
-			var msg string
-			if len(message) > 0 {
-				msg = message[0]
-			} else {
-				msg = fmt.Sprintf("%s should contain %s", f.name, substr)
-			}
-
-			return errors.New(msg)
+			return ruleMessage(message, CodeContains, f.name, map[string]any{"substring": substr})
 		}
 
 		return nil
@@ -204,20 +133,12 @@ func (f *StringField) Contains(substr string, message ...string) *StringField {
 
 // Email checks if the field value is a valid email address
 func (f *StringField) Email(message ...string) *StringField {
-	fv := *f.value
 	code := CodeEmail
 
-	validator := func() error {
+	validator := func(fv string) error {
 		isEmail := emailRegex.MatchString(fv)
 		if !isEmail {
-			var msg string
-			if len(message) > 0 {
-				msg = message[0]
-			} else {
-				msg = fmt.Sprintf("%s is not a valid email", f.name)
-			}
-
-			return errors.New(msg)
+			return ruleMessage(message, CodeEmail, f.name, nil)
 		}
 
 		return nil
@@ -229,20 +150,12 @@ func (f *StringField) Email(message ...string) *StringField {
 
 // UUID checks if the field value is a valid UUID
 func (f *StringField) UUID(message ...string) *StringField {
-	fv := *f.value
 	code := CodeUUID
 
-	validator := func() error {
+	validator := func(fv string) error {
 		isEmail := uuidRegex.MatchString(fv)
 		if !isEmail {
-			var msg string
-			if len(message) > 0 {
-				msg = message[0]
-			} else {
-				msg = fmt.Sprintf("%s is not a valid UUID", f.name)
-			}
-
-			return errors.New(msg)
+			return ruleMessage(message, CodeUUID, f.name, nil)
 		}
 
 		return nil
@@ -252,25 +165,24 @@ func (f *StringField) UUID(message ...string) *StringField {
 	return f
 }
 
-// URL checks if the field value is a valid URL
+// URL checks if the field value is a valid URL with a scheme and a host
 func (f *StringField) URL(message ...string) *StringField {
-	fv := *f.value
-	code := CodeURL
+	return f.URLWith(URLOptions{RequireHost: true}, message...)
+}
 
-	validator := func() error {
-		isURL := urlRegex.MatchString(fv)
-		if !isURL {
-			var msg string
-			if len(message) > 0 {
-				msg = message[0]
-			} else {
-				msg = fmt.Sprintf("%s is not a valid URL", f.name)
-			}
+// URLWith checks if the field value is a valid URL that satisfies the provided options.
+// Failures of the options are reported with their own CodeURL* code.
+func (f *StringField) URLWith(options URLOptions, message ...string) *StringField {
+	code := CodeURL
 
-			return errors.New(msg)
+	validator := func(fv string) error {
+		err := checkURL(f.name, fv, options)
+		if e, ok := err.(ruleError); ok && len(message) > 0 {
+			e.custom = message[0]
+			return e
 		}
 
-		return nil
+		return err
 	}
 
 	f.addValidation(validator, code)
@@ -279,19 +191,11 @@ func (f *StringField) URL(message ...string) *StringField {
 
 // EndsWith checks if the field value ends with the provided value
 func (f *StringField) EndsWith(value string, message ...string) *StringField {
-	fv := *f.value
 	code := CodeEndsWith
 
-	validator := func() error {
+	validator := func(fv string) error {
 		if !strings.HasSuffix(fv, value) {
-			var msg string
-			if len(message) > 0 {
-				msg = message[0]
-			} else {
-				msg = fmt.Sprintf("%s does not ends with %s", f.name, value)
-			}
-
-			return errors.New(msg)
+			return ruleMessage(message, CodeEndsWith, f.name, map[string]any{"suffix": value})
 		}
 		return nil
 	}
@@ -302,19 +206,11 @@ func (f *StringField) EndsWith(value string, message ...string) *StringField {
 
 // StartsWith checks if the field value starts with the provided value
 func (f *StringField) StartsWith(value string, message ...string) *StringField {
-	fv := *f.value
 	code := CodeStartsWith
 
-	validator := func() error {
+	validator := func(fv string) error {
 		if !strings.HasPrefix(fv, value) {
-			var msg string
-			if len(message) > 0 {
-				msg = message[0]
-			} else {
-				msg = fmt.Sprintf("%s does not starts with %s", f.name, value)
-			}
-
-			return errors.New(msg)
+			return ruleMessage(message, CodeStartsWith, f.name, map[string]any{"prefix": value})
 		}
 		return nil
 	}
@@ -325,20 +221,12 @@ func (f *StringField) StartsWith(value string, message ...string) *StringField {
 
 // Alpha checks if the field value contains only alphabets
 func (f *StringField) Alpha(message ...string) *StringField {
-	fv := *f.value
 	code := CodeAlpha
 
-	validator := func() error {
+	validator := func(fv string) error {
 		isAlpha := alphaRegex.MatchString(fv)
 		if !isAlpha {
-			var msg string
-			if len(message) > 0 {
-				msg = message[0]
-			} else {
-				msg = fmt.Sprintf("%s should contain only alphabets", f.name)
-			}
-
-			return errors.New(msg)
+			return ruleMessage(message, CodeAlpha, f.name, nil)
 		}
 
 		return nil
@@ -350,20 +238,12 @@ func (f *StringField) Alpha(message ...string) *StringField {
 
 // Numeric checks if the field value contains only numbers
 func (f *StringField) Numeric(message ...string) *StringField {
-	fv := *f.value
-	code := "numeric"
+	code := CodeNumeric
 
-	validator := func() error {
+	validator := func(fv string) error {
 		isNumeric := numericRegex.MatchString(fv)
 		if !isNumeric {
-			var msg string
-			if len(message) > 0 {
-				msg = message[0]
-			} else {
-				msg = fmt.Sprintf("%s should contain only numbers", f.name)
-			}
-
-			return errors.New(msg)
+			return ruleMessage(message, CodeNumeric, f.name, nil)
 		}
 
 		return nil
@@ -375,20 +255,12 @@ func (f *StringField) Numeric(message ...string) *StringField {
 
 // AlphaNumeric checks if the field value contains only alphabets and numbers
 func (f *StringField) AlphaNumeric(message ...string) *StringField {
-	fv := *f.value
 	code := CodeAlphaNumeric
 
-	validator := func() error {
+	validator := func(fv string) error {
 		isAlphaNumeric := alphaNumericRegex.MatchString(fv)
 		if !isAlphaNumeric {
-			var msg string
-			if len(message) > 0 {
-				msg = message[0]
-			} else {
-				msg = fmt.Sprintf("%s should contain only alphabets and numbers", f.name)
-			}
-
-			return errors.New(msg)
+			return ruleMessage(message, CodeAlphaNumeric, f.name, nil)
 		}
 
 		return nil
@@ -400,24 +272,16 @@ func (f *StringField) AlphaNumeric(message ...string) *StringField {
 
 // IsOneOf checks if the field value is one of the values passed in the slice
 func (f *StringField) IsOneOf(values []string, message ...string) *StringField {
-	fv := *f.value
 	code := CodeIsOneOf
 
-	validator := func() error {
+	validator := func(fv string) error {
 		for _, value := range values {
 			if value == fv {
 				return nil
 			}
 		}
 
-		var msg string
-		if len(message) > 0 {
-			msg = message[0]
-		} else {
-			msg = fmt.Sprintf("%s can only be %s", f.name, strings.Join(values, ", "))
-		}
-
-		return errors.New(msg)
+		return ruleMessage(message, CodeIsOneOf, f.name, map[string]any{"allowed": values})
 	}
 
 	f.addValidation(validator, code)
@@ -444,8 +308,56 @@ func (f *StringField) ToLowerCase() *StringField {
 	return f
 }
 
+// When adds the rules of 'then' to the field, but only applies them if 'cond' returns true when the field is parsed.
+// 'then' receives a new field with the same name to add the rules to.
+func (f *StringField) When(cond func() bool, then func(*StringField)) *StringField {
+	sub := String(nil, f.name)
+	then(sub)
+
+	f.dependent = true
+	f.addNested(func(ctx context.Context, value *string, errs *Collector) bool {
+		return !cond() || sub.ParseValueTo(ctx, value, errs)
+	})
+	return f
+}
+
+// Unless is like When, but applies the rules of 'then' only if 'cond' returns false
+func (f *StringField) Unless(cond func() bool, then func(*StringField)) *StringField {
+	return f.When(func() bool { return !cond() }, then)
+}
+
+// RequiredIf makes the field required if 'cond' returns true when the field is parsed, and optional otherwise.
+// Nil and zero values count as missing, and the rules of a missing optional field are skipped.
+func (f *StringField) RequiredIf(cond func() bool) *StringField {
+	f.presence.requireIf(cond)
+	return f
+}
+
+// RequiredUnless makes the field required if 'cond' returns false, see RequiredIf
+func (f *StringField) RequiredUnless(cond func() bool) *StringField {
+	return f.RequiredIf(func() bool { return !cond() })
+}
+
+// RequiredWith makes the field required if any of the values pointed to by 'others' is present, see RequiredIf
+func (f *StringField) RequiredWith(others ...any) *StringField {
+	return f.RequiredIf(func() bool { return anyPresent(others) })
+}
+
+// ExcludedWith reports the field with CodeExcluded if it is present while any of the values pointed to by 'others' is.
+// The rules of the field are skipped while it is excluded.
+func (f *StringField) ExcludedWith(others ...any) *StringField {
+	f.presence.excludeIf(func() bool { return anyPresent(others) })
+	return f
+}
+
 // Refine lets you provide custom validation logic
 func (f *StringField) Refine(fn func(field string) error, refinementData ...RefinementData) *StringField {
+	return f.RefineCtx(func(_ context.Context, value string) error { return fn(value) }, refinementData...)
+}
+
+// RefineCtx is like Refine, but 'fn' also receives the context passed to ParseCtx.
+// An error returned after the context is done is not reported as a refinement error.
+func (f *StringField) RefineCtx(fn func(context.Context, string) error, refinementData ...RefinementData) *StringField {
 	var newRefinementData RefinementData
 	if len(refinementData) > 0 {
 		newRefinementData = refinementData[0]
@@ -462,22 +374,43 @@ func (f *StringField) Transform(fn func(string) string) *StringField {
 }
 
 // Parse parses the field and returns a slice of Error.
-func (f *StringField) Parse() []Error {
-	var errs []Error
-	f._parse(&errs)
-	return errs
+func (f *StringField) Parse(opts ...ParseOption) []Error {
+	return f.ParseCtx(context.Background(), opts...)
+}
+
+// ParseCtx parses the field like Parse and passes 'ctx' to the refinements added with RefineCtx.
+// When 'ctx' is done the parse stops and a CodeCanceled or CodeDeadlineExceeded error is reported.
+func (f *StringField) ParseCtx(ctx context.Context, opts ...ParseOption) []Error {
+	errs := newCollector(opts)
+	f.ParseTo(ctx, &errs)
+	return parseResult(ctx, f.name, &errs, opts)
+}
+
+// ParseValue parses the provided value instead of the bound one and returns a slice of Error.
+func (f *StringField) ParseValue(value *string, opts ...ParseOption) []Error {
+	return f.ParseValueCtx(context.Background(), value, opts...)
+}
+
+// ParseValueCtx parses the provided value like ParseValue, with the context handling of ParseCtx.
+func (f *StringField) ParseValueCtx(ctx context.Context, value *string, opts ...ParseOption) []Error {
+	errs := newCollector(opts)
+	f.ParseValueTo(ctx, value, &errs)
+	return parseResult(ctx, f.name, &errs, opts)
+}
+
+// Validate parses the field and returns the errors as Errors, or nil if the field is valid.
+func (f *StringField) Validate(opts ...ParseOption) error {
+	return asError(f.Parse(opts...))
+}
+
+// ValidateValue parses the provided value like ParseValue and returns the errors as Errors, or nil if the value is valid.
+func (f *StringField) ValidateValue(value *string, opts ...ParseOption) error {
+	return asError(f.ParseValue(value, opts...))
 }
 
 // String takes a pointer to a string and a variadic argument 'name'.
 // Even if multiple values are passed for 'name', only the first value will be considered.
+// Pass a nil pointer to declare a reusable schema and apply it with ParseValue.
 func String(value *string, name ...string) *StringField {
-	field := StringField{
-		value: value,
-	}
-
-	if len(name) > 0 {
-		field.name = name[0]
-	}
-
-	return &field
+	return &StringField{fieldCore: newFieldCore(value, name)}
 }
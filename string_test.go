@@ -98,22 +98,6 @@ func TestStringContains(t *testing.T) {
 // 		t.Errorf("expected no error")
 // 	}
 
-// 	errs = String(&badInput, field).Min(value).Parse()
-// 	if len(errs) == 0 {
-// 		t.Fatal("expected error")
-// 	}
-// }
-// func TestURL(t *testing.T) {
-// 	value := 5
-// 	goodInput := "aadi"
-// 	badInput := "aaditya"
-// 	var errs []Error
-
-// 	errs = String(&goodInput, field).Min(value).Parse()
-// 	if len(errs) > 0 {
-// 		t.Errorf("expected no error")
-// 	}
-
 //		errs = String(&badInput, field).Min(value).Parse()
 //		if len(errs) == 0 {
 //			t.Fatal("expected error")
@@ -177,8 +161,8 @@ func TestStringNumeric(t *testing.T) {
 	}
 
 	errs = String(&badInput).Numeric().Parse()
-	if len(errs) == 0 {
-		t.Fatal("expected error")
+	if len(errs) == 0 || errs[0].Code != CodeNumeric {
+		t.Fatalf("expected numeric error, got %v", errs)
 	}
 }
 func TestStringAlphaNumeric(t *testing.T) {
@@ -279,3 +263,57 @@ func TestStringTransform(t *testing.T) {
 		t.Errorf("input not transformed properly")
 	}
 }
+
+func TestStringTransformBeforeValidation(t *testing.T) {
+	input := "  AaDiTyA  "
+
+	errs := String(&input).TrimSpace().ToLowerCase().Length(7).IsOneOf([]string{"aaditya"}).Parse()
+	if len(errs) > 0 {
+		t.Errorf("expected no error")
+	}
+
+	input = "aaditya"
+	errs = String(&input).Length(7).Transform(func(s string) string { return s + "!" }).Alpha().Parse()
+	if len(errs) == 0 {
+		t.Fatal("expected error")
+	}
+}
+
+func TestStringOptionalNilPointer(t *testing.T) {
+	var input *string
+
+	errs := String(input).Optional().Min(5).Email().Parse()
+	if len(errs) > 0 {
+		t.Errorf("expected no error")
+	}
+
+	errs = String(input).Min(5).Parse()
+	if len(errs) == 0 || errs[0].Code != CodeRequired {
+		t.Fatal("expected required error")
+	}
+}
+
+func TestStringParseValue(t *testing.T) {
+	schema := String(nil, "name").TrimSpace().Min(5)
+	goodInput := "  aaditya  "
+	badInput := "  aadi  "
+
+	errs := schema.ParseValue(&goodInput)
+	if len(errs) > 0 {
+		t.Errorf("expected no error")
+	}
+
+	if goodInput != "aaditya" {
+		t.Errorf("input not transformed properly")
+	}
+
+	errs = schema.ParseValue(&badInput)
+	if len(errs) == 0 {
+		t.Fatal("expected error")
+	}
+
+	errs = schema.ParseValue(nil)
+	if len(errs) == 0 || errs[0].Code != CodeRequired {
+		t.Fatal("expected required error")
+	}
+}
@@ -1,70 +1,50 @@
 package validator
 
-import "reflect"
-
-type structAction[T any] struct {
-	field          field
-	refinement     func(T) error
-	transformer    func(T) T
-	refinementData RefinementData
-}
+import (
+	"context"
+	"reflect"
+	"sync"
+)
 
 type StructField[T any] struct {
-	value         *T
-	name          string
-	optional      bool
-	requiredError string
-	actions       []structAction[T]
-	abortEarly    bool
+	fieldCore[T]
+	concurrency int
+	// members holds the snapshot functions of the fields passed to Fields
+	members []func() (restore func())
 }
 
-func (f *StructField[T]) addRefinement(fn func(T) error, refinementData RefinementData) {
-	action := structAction[T]{refinement: fn, refinementData: refinementData}
-	f.actions = append(f.actions, action)
-}
+func (f *StructField[T]) ParseTo(ctx context.Context, errs *Collector) bool {
+	value, ok, done := f.boundValue(errs)
+	if done {
+		return ok
+	}
 
-func (f *StructField[T]) addTransformer(fn func(T) T) {
-	action := structAction[T]{transformer: fn}
-	f.actions = append(f.actions, action)
+	return f.ParseValueTo(ctx, value, errs)
 }
 
-func (f *StructField[T]) _parse(errs *[]Error) bool {
-	if f.value == nil {
-		if !f.optional {
-			*errs = append(*errs, requiredFieldErr(f.name, f.requiredError))
-			return false
-		}
-
-		return true
+func (f *StructField[T]) ParseValueTo(ctx context.Context, value *T, errs *Collector) bool {
+	if ok, done := f.checkValue(value, errs); done {
+		return ok
 	}
 
-	if reflect.ValueOf(f.value).Elem().Kind() != reflect.Struct {
-		*errs = append(*errs, Error{Field: f.name, Message: "value must be a struct", Code: CodeInvalidType})
+	if reflect.ValueOf(value).Elem().Kind() != reflect.Struct {
+		errs.Add(newError(f.name, "value must be a struct", CodeInvalidType))
 		return false
 	}
 
 	isFieldParsedSuccessfully := true
-	for _, action := range f.actions {
-		ok := true
-		if action.field != nil {
-			ok = action.field._parse(errs)
-		} else if action.refinement != nil {
-			err := action.refinement(*f.value)
-			if err != nil {
-				ok = false
-				me := Error{Field: f.name, Message: err.Error(), Code: CodeRefinement}
-				if action.refinementData.Field != "" {
-					me.Field = action.refinementData.Field
-				}
-				if action.refinementData.Code != "" {
-					me.Code = action.refinementData.Code
-				}
+	for i := 0; i < len(f.actions); {
+		if errs.Done(ctx) {
+			return false
+		}
 
-				*errs = append(*errs, me)
-			}
-		} else if action.transformer != nil {
-			*f.value = action.transformer(*f.value)
-			continue
+		var ok bool
+		if n := f.batchSize(i); n > 1 {
+			ok = f.parseBatch(ctx, value, f.actions[i:i+n], errs)
+			i += n
+		} else {
+			ok = f.parseAction(ctx, value, f.actions[i], errs)
+			i++
 		}
 
 		if !ok {
@@ -78,6 +58,132 @@ func (f *StructField[T]) _parse(errs *[]Error) bool {
 	return isFieldParsedSuccessfully
 }
 
+// exclusiveField is implemented by the fields whose conditions read other fields of the struct,
+// like When and RequiredWith. They are parsed on their own in Concurrent structs.
+type exclusiveField interface {
+	exclusive() bool
+}
+
+// addMember adds a nested action whose errors are reported inside the struct.
+func (f *StructField[T]) addMember(member StructMember[T]) {
+	nested := func(ctx context.Context, value *T, errs *Collector) bool {
+		start := errs.Len()
+		ok := member.parse(ctx, value, errs)
+		errs.Prefix(start, fieldPath(f.name)...)
+		return ok
+	}
+	f.actions = append(f.actions, fieldAction[T]{nested: nested, exclusive: member.exclusive})
+	if member.snapshot != nil {
+		f.members = append(f.members, member.snapshot)
+	}
+}
+
+// snapshot saves the struct and the values of its fields, which may not be part of the struct.
+// The struct is restored first, since a copy of it still shares the elements of its slices and maps with the value.
+func (f *StructField[T]) snapshot() (restore func()) {
+	restores := []func(){f.fieldCore.snapshot()}
+	for _, snapshot := range f.members {
+		restores = append(restores, snapshot())
+	}
+
+	return func() {
+		for _, restore := range restores {
+			restore()
+		}
+	}
+}
+
+// batchSize returns the number of actions starting at 'i' that can run concurrently.
+// Fields, members and independent refinements can, while transformers, other refinements and the actions that work on
+// the whole struct or read other fields, like When and RequiredWith, wait for the actions before them and hold back the actions after them.
+func (f *StructField[T]) batchSize(i int) int {
+	if f.concurrency <= 1 {
+		return 1
+	}
+
+	n := 0
+	for _, action := range f.actions[i:] {
+		if action.transformer != nil || action.exclusive || (action.refinement != nil && !action.refinementData.Independent) {
+			break
+		}
+		n++
+	}
+
+	return n
+}
+
+// parseBatch runs the actions on a pool of at most f.concurrency goroutines.
+// The errors are merged in the order the actions were added, so the result doesn't depend on scheduling.
+// With AbortEarly, a failed action cancels the actions after it, which gives the same errors as a sequential parse.
+// So does an action that reaches the error limit of the parse on its own.
+func (f *StructField[T]) parseBatch(ctx context.Context, value *T, actions []fieldAction[T], errs *Collector) bool {
+	type result struct {
+		errs Collector
+		ok   bool
+	}
+
+	limit := errs.remaining()
+	// refinements get a copy of the value, so that they don't race with the fields writing to it
+	snapshot := *value
+	results := make([]result, len(actions))
+	contexts := make([]context.Context, len(actions))
+	cancels := make([]context.CancelFunc, len(actions))
+	for i := range actions {
+		contexts[i], cancels[i] = context.WithCancel(ctx)
+		defer cancels[i]()
+	}
+
+	sem := make(chan struct{}, f.concurrency)
+	var wg sync.WaitGroup
+	for i, action := range actions {
+		sem <- struct{}{}
+		if contexts[i].Err() != nil {
+			<-sem
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, action fieldAction[T]) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			target := value
+			if action.refinement != nil {
+				target = &snapshot
+			}
+
+			r := &results[i]
+			r.errs.limit = limit
+			r.ok = f.parseAction(contexts[i], target, action, &r.errs)
+
+			if !r.ok && (f.abortEarly || r.errs.full()) {
+				for _, cancel := range cancels[i+1:] {
+					cancel()
+				}
+			}
+		}(i, action)
+	}
+	wg.Wait()
+
+	ok := true
+	for i := range results {
+		if errs.Done(ctx) {
+			return false
+		}
+
+		r := &results[i]
+		errs.merge(&r.errs)
+		if !r.ok {
+			ok = false
+			if f.abortEarly {
+				break
+			}
+		}
+	}
+
+	return ok
+}
+
 // AbortEarly stops the parsing of the field on the first error
 func (f *StructField[T]) AbortEarly() *StructField[T] {
 	f.abortEarly = true
@@ -96,17 +202,118 @@ func (f *StructField[T]) RequiredError(message string) *StructField[T] {
 	return f
 }
 
+// Default sets the value of the field to 'value' when it is missing, before the rules run.
+// A value is missing when its Patch is absent or null, or when it is empty with EmptyAsMissing, see FromPatch.
+// A nil pointer can't hold the default, so it is still required unless the field is Optional.
+func (f *StructField[T]) Default(value T) *StructField[T] {
+	return f.DefaultFunc(func() T { return value })
+}
+
+// DefaultFunc is like Default, but calls 'fn' for the default every time the value is missing.
+func (f *StructField[T]) DefaultFunc(fn func() T) *StructField[T] {
+	f.defaultValue = fn
+	return f
+}
+
+// Nullable accepts null values, a nil pointer or a null Patch, and skips the rules for them.
+// Without it, null counts as missing.
+func (f *StructField[T]) Nullable() *StructField[T] {
+	f.nullable = true
+	return f
+}
+
+// EmptyAsMissing treats the zero value of the field, or an empty slice or map, as missing.
+// It then gets the default, or is reported as required unless the field is Optional.
+func (f *StructField[T]) EmptyAsMissing() *StructField[T] {
+	f.emptyAsMissing = true
+	return f
+}
+
+// Concurrent parses the fields and members of the struct, and the refinements marked as Independent,
+// on up to 'limit' goroutines. The errors are reported in the same order as in a sequential parse.
+// Transformers and the other refinements still run in order, after the actions added before them.
+func (f *StructField[T]) Concurrent(limit int) *StructField[T] {
+	f.concurrency = limit
+	return f
+}
+
 // Fields take in fields of the struct and validates them
-func (f *StructField[T]) Fields(fields ...field) *StructField[T] {
+func (f *StructField[T]) Fields(fields ...Field) *StructField[T] {
 	for _, field := range fields {
-		action := structAction[T]{field: field}
-		f.actions = append(f.actions, action)
+		m := StructMember[T]{parse: func(ctx context.Context, _ *T, errs *Collector) bool {
+			return field.ParseTo(ctx, errs)
+		}}
+		if s, ok := field.(snapshotter); ok {
+			m.snapshot = s.snapshot
+		}
+		if e, ok := field.(exclusiveField); ok {
+			m.exclusive = e.exclusive()
+		}
+		f.addMember(m)
 	}
 	return f
 }
 
+// Members take in members of the struct and validates them against the struct being parsed.
+// Unlike Fields, members are not bound to a value, so they can be used in a reusable schema.
+func (f *StructField[T]) Members(members ...StructMember[T]) *StructField[T] {
+	for _, member := range members {
+		f.addMember(member)
+	}
+	return f
+}
+
+// When adds the rules of 'then' to the field, but only applies them if 'cond' returns true when the field is parsed.
+// 'then' receives a new field with the same name to add the rules to.
+func (f *StructField[T]) When(cond func() bool, then func(*StructField[T])) *StructField[T] {
+	sub := Struct[T](nil)
+	then(sub)
+
+	f.dependent = true
+	// the rules of 'then' may transform the whole struct, so they never run concurrently with the other fields
+	f.addMember(StructMember[T]{exclusive: true, parse: func(ctx context.Context, value *T, errs *Collector) bool {
+		return !cond() || sub.ParseValueTo(ctx, value, errs)
+	}})
+	return f
+}
+
+// Unless is like When, but applies the rules of 'then' only if 'cond' returns false
+func (f *StructField[T]) Unless(cond func() bool, then func(*StructField[T])) *StructField[T] {
+	return f.When(func() bool { return !cond() }, then)
+}
+
+// RequiredIf makes the field required if 'cond' returns true when the field is parsed, and optional otherwise.
+// Nil and zero values count as missing, and the rules of a missing optional field are skipped.
+func (f *StructField[T]) RequiredIf(cond func() bool) *StructField[T] {
+	f.presence.requireIf(cond)
+	return f
+}
+
+// RequiredUnless makes the field required if 'cond' returns false, see RequiredIf
+func (f *StructField[T]) RequiredUnless(cond func() bool) *StructField[T] {
+	return f.RequiredIf(func() bool { return !cond() })
+}
+
+// RequiredWith makes the field required if any of the values pointed to by 'others' is present, see RequiredIf
+func (f *StructField[T]) RequiredWith(others ...any) *StructField[T] {
+	return f.RequiredIf(func() bool { return anyPresent(others) })
+}
+
+// ExcludedWith reports the field with CodeExcluded if it is present while any of the values pointed to by 'others' is.
+// The rules of the field are skipped while it is excluded.
+func (f *StructField[T]) ExcludedWith(others ...any) *StructField[T] {
+	f.presence.excludeIf(func() bool { return anyPresent(others) })
+	return f
+}
+
 // Refine lets you provide custom validation logic
 func (f *StructField[T]) Refine(fn func(T) error, refinementData ...RefinementData) *StructField[T] {
+	return f.RefineCtx(func(_ context.Context, value T) error { return fn(value) }, refinementData...)
+}
+
+// RefineCtx is like Refine, but 'fn' also receives the context passed to ParseCtx.
+// An error returned after the context is done is not reported as a refinement error.
+func (f *StructField[T]) RefineCtx(fn func(context.Context, T) error, refinementData ...RefinementData) *StructField[T] {
 	var newRefinementData RefinementData
 	if len(refinementData) > 0 {
 		newRefinementData = refinementData[0]
@@ -123,22 +330,70 @@ func (f *StructField[T]) Transform(fn func(T) T) *StructField[T] {
 }
 
 // Parse parses the field and returns a slice of Error.
-func (f *StructField[T]) Parse() []Error {
-	var errs []Error
-	f._parse(&errs)
-	return errs
+func (f *StructField[T]) Parse(opts ...ParseOption) []Error {
+	return f.ParseCtx(context.Background(), opts...)
+}
+
+// ParseCtx parses the field like Parse and passes 'ctx' to the refinements added with RefineCtx.
+// When 'ctx' is done the parse stops and a CodeCanceled or CodeDeadlineExceeded error is reported.
+func (f *StructField[T]) ParseCtx(ctx context.Context, opts ...ParseOption) []Error {
+	errs := newCollector(opts)
+	f.ParseTo(ctx, &errs)
+	return parseResult(ctx, f.name, &errs, opts)
+}
+
+// ParseValue parses the provided value instead of the bound one and returns a slice of Error.
+func (f *StructField[T]) ParseValue(value *T, opts ...ParseOption) []Error {
+	return f.ParseValueCtx(context.Background(), value, opts...)
+}
+
+// ParseValueCtx parses the provided value like ParseValue, with the context handling of ParseCtx.
+func (f *StructField[T]) ParseValueCtx(ctx context.Context, value *T, opts ...ParseOption) []Error {
+	errs := newCollector(opts)
+	f.ParseValueTo(ctx, value, &errs)
+	return parseResult(ctx, f.name, &errs, opts)
+}
+
+// Validate parses the field and returns the errors as Errors, or nil if the field is valid.
+func (f *StructField[T]) Validate(opts ...ParseOption) error {
+	return asError(f.Parse(opts...))
+}
+
+// ValidateValue parses the provided value like ParseValue and returns the errors as Errors, or nil if the value is valid.
+func (f *StructField[T]) ValidateValue(value *T, opts ...ParseOption) error {
+	return asError(f.ParseValue(value, opts...))
 }
 
 // Struct takes a pointer to a struct and a variadic argument 'name'.
 // Even if multiple values are passed for 'name', only the first value will be considered.
+// Pass a nil pointer to declare a reusable schema and apply it with ParseValue.
 func Struct[T any](value *T, name ...string) *StructField[T] {
-	field := StructField[T]{
-		value: value,
-	}
+	field := StructField[T]{fieldCore: newFieldCore(value, name)}
+	field.scoped = true
+	return &field
+}
 
-	if len(name) > 0 {
-		field.name = name[0]
-	}
+// StructMember is a rule of a struct of type T that is parsed against the value of the struct, see Members.
+// It is built by Member, MemberFunc and the cross-field rules such as EqualFields.
+type StructMember[T any] struct {
+	parse func(ctx context.Context, value *T, errs *Collector) bool
+	// exclusive members work on more than one field of the struct, see fieldAction
+	exclusive bool
+	// snapshot is set for bound fields that can save their value, see fieldCore.snapshot
+	snapshot func() (restore func())
+}
 
-	return &field
+// MemberFunc returns a member that calls 'parse' with the struct every time the struct is parsed.
+// 'parse' adds its errors to the Collector, which reports them inside the struct, and returns false if there were any.
+// It may read and transform any field of the struct, so it is never parsed concurrently with other fields.
+func MemberFunc[T any](parse func(ctx context.Context, value *T, errs *Collector) bool) StructMember[T] {
+	return StructMember[T]{exclusive: true, parse: parse}
+}
+
+// Member binds a field schema to the struct field returned by 'get'.
+// The schema is parsed against that struct field every time the parent struct is parsed.
+func Member[T, V any](get func(*T) *V, schema Schema[V]) StructMember[T] {
+	return StructMember[T]{parse: func(ctx context.Context, value *T, errs *Collector) bool {
+		return schema.ParseValueTo(ctx, get(value), errs)
+	}}
 }
@@ -1,9 +1,14 @@
 package validator
 
 import (
+	"context"
 	"errors"
+	"net"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestStruct(t *testing.T) {
@@ -98,3 +103,591 @@ func TestStructTransform(t *testing.T) {
 		t.Error("input not transformed properly")
 	}
 }
+
+func TestStructTransformBeforeValidation(t *testing.T) {
+
+	type User struct {
+		name, email string
+	}
+
+	user := User{
+		name:  "aaditya",
+		email: "AADITYA220055@GMAIL.COM",
+	}
+
+	errs := Struct(&user).
+		Transform(func(u User) User {
+			u.email = strings.ToLower(u.email)
+			return u
+		}).
+		Refine(func(u User) error {
+			if u.email != strings.ToLower(u.email) {
+				return errors.New("email not lowercased")
+			}
+
+			return nil
+		}).
+		Parse()
+
+	if len(errs) > 0 {
+		t.Error("expected no error")
+	}
+}
+
+func TestStructOptionalNilPointer(t *testing.T) {
+
+	type User struct {
+		name string
+	}
+
+	var user *User
+
+	errs := Struct(user).Optional().Parse()
+	if len(errs) > 0 {
+		t.Error("expected no error")
+	}
+}
+
+func TestStructMembers(t *testing.T) {
+
+	type User struct {
+		name  string
+		email string
+		age   int
+	}
+
+	schema := Struct[User](nil, "user").
+		Members(
+			Member(func(u *User) *string { return &u.name }, String(nil, "name").Alpha()),
+			Member(func(u *User) *string { return &u.email }, String(nil, "email").ToLowerCase().Email()),
+			Member(func(u *User) *int { return &u.age }, Number[int](nil, "age").Min(18)),
+		)
+
+	goodInput := User{name: "aaditya", email: "AADITYA220055@GMAIL.COM", age: 21}
+	badInput := User{name: "aadi@23", email: "aaditya", age: 17}
+
+	errs := schema.ParseValue(&goodInput)
+	if len(errs) > 0 {
+		t.Error("expected no error")
+	}
+
+	if goodInput.email != "aaditya220055@gmail.com" {
+		t.Error("input not transformed properly")
+	}
+
+	errs = schema.ParseValue(&badInput)
+	if len(errs) != 3 {
+		t.Errorf("expected 3 errors, got %d", len(errs))
+	}
+}
+
+func TestMemberFunc(t *testing.T) {
+
+	type User struct {
+		name     string
+		nickname string
+	}
+
+	members := []StructMember[User]{
+		Member(func(u *User) *string { return &u.name }, String(nil, "name").Min(3)),
+		MemberFunc(func(ctx context.Context, u *User, errs *Collector) bool {
+			if u.nickname == "" {
+				u.nickname = u.name
+			}
+			if u.nickname == "admin" {
+				errs.AddError("nickname", "nickname is reserved", "reserved")
+				return false
+			}
+			return true
+		}),
+	}
+
+	user := User{name: "aaditya"}
+	if errs := Struct[User](nil, "user").Members(members...).ParseValue(&user); len(errs) > 0 || user.nickname != "aaditya" {
+		t.Errorf("expected the nickname to default to the name, got %q %v", user.nickname, errs)
+	}
+
+	user = User{name: "admin"}
+	errs := Struct[User](nil, "user").Members(members...).ParseValue(&user)
+	if len(errs) != 1 || errs[0].Field != "user.nickname" || errs[0].Code != "reserved" {
+		t.Errorf("expected a reserved error for the nickname, got %v", errs)
+	}
+}
+
+func TestStructMembersConcurrent(t *testing.T) {
+
+	type User struct {
+		name string
+	}
+
+	schema := Struct[User](nil).
+		Members(Member(func(u *User) *string { return &u.name }, String(nil, "name").TrimSpace().Min(3)))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			user := User{name: " ab "}
+			if i%2 == 0 {
+				user.name = " aaditya "
+			}
+
+			errs := schema.ParseValue(&user)
+			if (i%2 == 0) != (len(errs) == 0) {
+				t.Errorf("unexpected result for %q", user.name)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestStructNestedPath(t *testing.T) {
+
+	type Address struct {
+		city string
+	}
+
+	type User struct {
+		address  Address
+		username string
+	}
+
+	user := User{username: "john@wick"}
+
+	errs := Struct(&user, "user").
+		Fields(
+			Struct(&user.address, "address").Fields(String(&user.address.city, "city").Min(1)),
+		).
+		Refine(func(u User) error {
+			if strings.Contains(u.username, "@") {
+				return errors.New("username should not contain '@'")
+			}
+
+			return nil
+		}, RefinementData{Field: "username"}).
+		Parse()
+
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %d", len(errs))
+	}
+
+	if errs[0].Field != "user.address.city" || len(errs[0].Path) != 3 || errs[0].Path[1].Name != "address" {
+		t.Errorf("unexpected path %q %v", errs[0].Field, errs[0].Path)
+	}
+
+	if errs[1].Field != "user.username" {
+		t.Errorf("unexpected path %q", errs[1].Field)
+	}
+}
+
+func TestStructPathSegments(t *testing.T) {
+
+	type Item struct {
+		tags map[string][]string
+	}
+
+	type Order struct {
+		items []Item
+	}
+
+	order := Order{items: []Item{{}, {tags: map[string][]string{"color": {"red", ""}}}}}
+
+	tags := Map[string, []string](nil, "tags").Values(Slice[string](nil).Each(String(nil).Min(1)))
+	errs := Struct(&order, "order").
+		Fields(
+			Slice(&order.items, "items").Each(Struct[Item](nil).Members(Member(func(i *Item) *map[string][]string { return &i.tags }, tags))),
+		).
+		Parse()
+
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %d", len(errs))
+	}
+
+	if errs[0].Field != `order.items[1].tags["color"][1]` {
+		t.Errorf("unexpected path %q", errs[0].Field)
+	}
+
+	expected := []PathSegment{
+		{Kind: SegmentField, Name: "order"},
+		{Kind: SegmentField, Name: "items"},
+		{Kind: SegmentIndex, Index: 1},
+		{Kind: SegmentField, Name: "tags"},
+		{Kind: SegmentKey, Key: "color"},
+		{Kind: SegmentIndex, Index: 1},
+	}
+
+	if len(errs[0].Path) != len(expected) {
+		t.Fatalf("unexpected path %v", errs[0].Path)
+	}
+
+	for i := range expected {
+		if errs[0].Path[i] != expected[i] {
+			t.Errorf("expected segment %v, got %v", expected[i], errs[0].Path[i])
+		}
+	}
+}
+
+func TestStructParseCtx(t *testing.T) {
+
+	type Account struct {
+		Username string
+		Email    string
+	}
+
+	type contextKey struct{}
+
+	account := Account{Username: "aaditya", Email: "me@mail.com"}
+	taken := errors.New("username is taken")
+
+	var got any
+	errs := Struct(&account, "account").
+		Fields(
+			String(&account.Username, "username").RefineCtx(func(ctx context.Context, s string) error {
+				got = ctx.Value(contextKey{})
+				return taken
+			}),
+		).
+		ParseCtx(context.WithValue(context.Background(), contextKey{}, "request"))
+
+	if got != "request" {
+		t.Errorf("expected the context to reach the nested refinement, got %v", got)
+	}
+
+	if len(errs) != 1 || errs[0].Field != "account.username" || errs[0].Code != CodeRefinement {
+		t.Errorf("expected refinement error for account.username, got %v", errs)
+	}
+}
+
+func TestStructParseCtxCanceled(t *testing.T) {
+
+	type Account struct {
+		Username string
+		Email    string
+	}
+
+	account := Account{Username: "aaditya", Email: "aaditya"}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	emailChecked := false
+	errs := Struct(&account, "account").
+		Fields(
+			String(&account.Username, "username").RefineCtx(func(ctx context.Context, s string) error {
+				cancel()
+				return ctx.Err()
+			}),
+			String(&account.Email, "email").Refine(func(s string) error {
+				emailChecked = true
+				return nil
+			}).Email(),
+		).
+		ParseCtx(ctx)
+
+	if emailChecked {
+		t.Error("expected the parse to stop once the context is canceled")
+	}
+
+	if len(errs) != 1 || errs[0].Code != CodeCanceled || errs[0].Field != "account" {
+		t.Errorf("expected a single canceled error, got %v", errs)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 0)
+	defer cancel()
+
+	errs = String(nil, "name").Optional().ParseValueCtx(ctx, &account.Username)
+	if len(errs) != 1 || errs[0].Code != CodeDeadlineExceeded {
+		t.Errorf("expected deadline exceeded error, got %v", errs)
+	}
+}
+
+func TestStructConcurrent(t *testing.T) {
+
+	type Form struct {
+		A, B, C, D string
+	}
+
+	var running, peak atomic.Int32
+	slow := func(s string) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+
+		time.Sleep(time.Duration(len(s)) * time.Millisecond)
+		return errors.New(s + " is invalid")
+	}
+
+	form := Form{A: "aaaaa", B: "b", C: "ccc", D: "dd"}
+	schema := func() *StructField[Form] {
+		return Struct(&form, "form").
+			Fields(
+				String(&form.A, "a").Refine(slow),
+				String(&form.B, "b").Refine(slow),
+				String(&form.C, "c").Refine(slow),
+			).
+			Refine(func(f Form) error {
+				return errors.New("form is invalid")
+			}, RefinementData{Field: "d", Independent: true}).
+			Concurrent(2)
+	}
+
+	expected := []string{"form.a", "form.b", "form.c", "form.d"}
+	for run := 0; run < 5; run++ {
+		errs := schema().Parse()
+		if len(errs) != len(expected) {
+			t.Fatalf("expected %d errors, got %v", len(expected), errs)
+		}
+
+		for i, field := range expected {
+			if errs[i].Field != field {
+				t.Errorf("run %d: expected error %d for %s, got %s", run, i, field, errs[i].Field)
+			}
+		}
+	}
+
+	if p := peak.Load(); p > 2 {
+		t.Errorf("expected at most 2 concurrent refinements, got %d", p)
+	}
+}
+
+func TestStructConcurrentAbortEarly(t *testing.T) {
+
+	type Form struct {
+		A, B, C string
+	}
+
+	form := Form{A: "a", B: "b", C: "c"}
+
+	// c either doesn't start or blocks until it is canceled, the timeout only keeps a broken parse from hanging
+	var uncanceled atomic.Int32
+	errs := Struct(&form, "form").
+		Fields(
+			String(&form.A, "a").Min(1),
+			String(&form.B, "b").Refine(func(s string) error {
+				return errors.New("b is invalid")
+			}),
+			String(&form.C, "c").RefineCtx(func(ctx context.Context, s string) error {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(10 * time.Second):
+					uncanceled.Add(1)
+					return errors.New("c is invalid")
+				}
+			}),
+		).
+		Concurrent(3).
+		AbortEarly().
+		Parse()
+
+	if len(errs) != 1 || errs[0].Field != "form.b" {
+		t.Errorf("expected only the error of b, got %v", errs)
+	}
+
+	if uncanceled.Load() > 0 {
+		t.Error("expected the refinement of c to be canceled")
+	}
+}
+
+func TestStructConcurrentTransform(t *testing.T) {
+
+	type Form struct {
+		Name string
+	}
+
+	form := Form{Name: "  aaditya  "}
+	errs := Struct(&form, "form").
+		Fields(String(&form.Name, "name").TrimSpace()).
+		Transform(func(f Form) Form {
+			f.Name = strings.ToUpper(f.Name)
+			return f
+		}).
+		Refine(func(f Form) error {
+			if f.Name != "AADITYA" {
+				return errors.New("name is not transformed")
+			}
+			return nil
+		}, RefinementData{Independent: true}).
+		Concurrent(4).
+		Parse()
+
+	if len(errs) > 0 {
+		t.Errorf("expected transformers to run between the batches, got %v", errs)
+	}
+}
+
+type ipField struct {
+	value *string
+	name  string
+}
+
+func (f ipField) ParseTo(ctx context.Context, c *Collector) bool {
+	return f.ParseValueTo(ctx, f.value, c)
+}
+
+func (f ipField) ParseValueTo(ctx context.Context, value *string, c *Collector) bool {
+	if value != nil && net.ParseIP(*value) != nil {
+		return true
+	}
+
+	c.AddError(f.name, f.name+" must be an ip address", "ip")
+	return false
+}
+
+func TestStructCustomField(t *testing.T) {
+
+	type Server struct {
+		host  string
+		peers []string
+	}
+
+	server := Server{host: "localhost", peers: []string{"10.0.0.1", "10.0.0.300"}}
+
+	errs := Struct(&server, "server").Fields(
+		ipField{value: &server.host, name: "host"},
+		Slice(&server.peers, "peers").Each(ipField{}),
+	).Parse()
+
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %v", errs)
+	}
+
+	if errs[0].Field != "server.host" || errs[0].Code != "ip" {
+		t.Errorf("unexpected error %v", errs[0])
+	}
+
+	if errs[1].Field != "server.peers[1]" || errs[1].Code != "ip" {
+		t.Errorf("unexpected error %v", errs[1])
+	}
+}
+
+func TestStructFailFast(t *testing.T) {
+
+	type Order struct {
+		id    string
+		items []string
+		tags  map[string]int
+	}
+
+	order := Order{items: []string{"", "a", ""}, tags: map[string]int{"x": 0}}
+
+	var parsed []string
+	schema := Struct(&order, "order").Fields(
+		String(&order.id, "id").Min(1),
+		Slice(&order.items, "items").Each(String(nil).Min(1).Refine(func(s string) error {
+			parsed = append(parsed, s)
+			return nil
+		})),
+		Map(&order.tags, "tags").Values(Number[int](nil).Min(1)),
+	)
+
+	errs := schema.Parse(WithFailFast())
+	if len(errs) != 1 || errs[0].Field != "order.id" {
+		t.Fatalf("expected only the first error, got %v", errs)
+	}
+
+	if !Errors(errs).Truncated() {
+		t.Error("expected the errors to be truncated")
+	}
+
+	if len(parsed) > 0 {
+		t.Errorf("expected the items to be skipped, got %v", parsed)
+	}
+
+	errs = schema.Parse(WithMaxErrors(2))
+	if len(errs) != 2 || errs[1].Field != "order.items[0]" || !Errors(errs).Truncated() {
+		t.Fatalf("expected 2 truncated errors, got %v", errs)
+	}
+
+	errs = schema.Parse(WithMaxErrors(4))
+	if len(errs) != 4 || Errors(errs).Truncated() {
+		t.Errorf("expected all 4 errors without truncation, got %v", errs)
+	}
+}
+
+func TestStructMaxErrorsConcurrent(t *testing.T) {
+
+	type Form struct {
+		a, b, c, d string
+	}
+
+	var form Form
+	errs := Struct(&form, "form").
+		Fields(
+			String(&form.a, "a").Min(1),
+			String(&form.b, "b").Min(1),
+			String(&form.c, "c").Min(1),
+			String(&form.d, "d").Min(1),
+		).
+		Concurrent(4).
+		Parse(WithMaxErrors(2))
+
+	if len(errs) != 2 || errs[0].Field != "form.a" || errs[1].Field != "form.b" || !Errors(errs).Truncated() {
+		t.Errorf("expected the first 2 errors in order, truncated, got %v", errs)
+	}
+}
+
+func TestStructConcurrentWhen(t *testing.T) {
+
+	type User struct {
+		name  string
+		email string
+	}
+
+	user := User{name: "  aaditya  ", email: "ME@MAIL.COM"}
+	errs := Struct(&user, "user").
+		Fields(
+			String(&user.name, "name").TrimSpace().Min(3),
+			String(&user.email, "email").Email(),
+		).
+		When(func() bool { return true }, func(s *StructField[User]) {
+			s.Transform(func(u User) User {
+				u.email = strings.ToLower(u.email)
+				return u
+			})
+		}).
+		Fields(String(&user.email, "email").Contains("@mail.com")).
+		Concurrent(4).
+		Parse()
+
+	if len(errs) > 0 {
+		t.Errorf("expected no error, got %v", errs)
+	}
+
+	if user.name != "aaditya" || user.email != "me@mail.com" {
+		t.Errorf("expected the transformations to be applied, got %+v", user)
+	}
+}
+
+func TestStructConcurrentConditions(t *testing.T) {
+
+	type User struct {
+		email   string
+		phone   string
+		country string
+		zip     string
+	}
+
+	user := User{email: "  ", country: " IN ", zip: "12a"}
+	errs := Struct(&user, "user").
+		Fields(
+			String(&user.email, "email").TrimSpace(),
+			String(&user.country, "country").TrimSpace(),
+			String(&user.phone, "phone").RequiredWith(&user.email),
+			When(func() bool { return user.country == "IN" }, String(&user.zip, "zip").Numeric()),
+			String(&user.zip, "zip").When(func() bool { return user.country == "IN" }, func(s *StringField) {
+				s.Length(6)
+			}),
+		).
+		Concurrent(4).
+		Parse()
+
+	if len(errs) != 2 || errs[0].Code != CodeNumeric || errs[1].Code != CodeLength {
+		t.Errorf("expected the conditions to see the trimmed fields, got %v", errs)
+	}
+}
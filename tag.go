@@ -0,0 +1,694 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// TagRule is a custom rule that can be used in the 'validate' struct tag once registered with RegisterTagRule.
+// It receives the value of the struct field and the parameter passed to the rule in the tag, if any.
+type TagRule func(value any, param string) error
+
+var (
+	tagRules     sync.Map // map[string]TagRule
+	tagSchemas   sync.Map // map[reflect.Type]*tagSchema
+	builtinRules = map[string]bool{
+		"required": true, "omitempty": true, "min": true, "max": true, "len": true, "email": true,
+		"uuid": true, "url": true, "alpha": true, "numeric": true, "alphanum": true, "contains": true,
+		"startswith": true, "endswith": true, "oneof": true, "trim": true, "lowercase": true, "is": true,
+	}
+)
+
+// RegisterTagRule makes 'rule' available in the 'validate' struct tag under 'name'.
+// When the rule fails, the error is reported with 'name' as its Code.
+// It panics if 'name' is empty, contains a ',' or '=', or is the name of a built-in rule.
+func RegisterTagRule(name string, rule TagRule) {
+	if name == "" || strings.ContainsAny(name, ",=") {
+		panic(fmt.Sprintf("validator: invalid tag rule name %q", name))
+	}
+	if builtinRules[name] {
+		panic(fmt.Sprintf("validator: tag rule %q is a built-in rule", name))
+	}
+
+	tagRules.Store(name, rule)
+
+	// schemas cached before the rule existed may have been rejected or built without it
+	tagSchemas.Range(func(key, _ any) bool {
+		tagSchemas.Delete(key)
+		return true
+	})
+}
+
+// ValidateStruct validates the struct pointed to by 'value' using the rules in the 'validate' tags of its fields,
+// recursing into nested structs and the structs held by slices, arrays and maps.
+// The fields of embedded structs are validated as fields of the parent, like encoding/json flattens them.
+// Field names are taken from the 'json' tag when present.
+//
+// The returned error is only non-nil if 'value' is not a pointer to a struct or one of the tags is invalid.
+// Validation failures are reported in the slice of Error.
+func ValidateStruct(value any, opts ...ParseOption) ([]Error, error) {
+	return ValidateStructCtx(context.Background(), value, opts...)
+}
+
+// ValidateStructCtx is like ValidateStruct, but stops when 'ctx' is done, see ParseCtx.
+func ValidateStructCtx(ctx context.Context, value any, opts ...ParseOption) ([]Error, error) {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("validator: ValidateStruct expects a non-nil pointer to a struct, got %T", value)
+	}
+
+	schema, err := tagSchemaOf(rv.Elem().Type())
+	if err != nil {
+		return nil, err
+	}
+
+	errs := newCollector(opts)
+	schema.bind(rv.Elem(), "").ParseTo(ctx, &errs)
+	return parseResult(ctx, "", &errs, opts), nil
+}
+
+type tagRule struct {
+	name  string
+	param string
+}
+
+type tagOptions struct {
+	name      string
+	required  bool
+	omitEmpty bool
+	rules     []tagRule
+}
+
+type tagField struct {
+	// index is the index sequence of the field, which is longer than one for the fields of embedded structs
+	index []int
+	name  string
+	bind  func(value reflect.Value) Field
+}
+
+type tagSchema struct {
+	fields []tagField
+}
+
+func (s *tagSchema) bind(value reflect.Value, name string) Field {
+	fields := make([]Field, 0, len(s.fields))
+	for _, tf := range s.fields {
+		field, err := value.FieldByIndexErr(tf.index)
+		if err != nil {
+			// the field is promoted from a nil embedded pointer
+			continue
+		}
+
+		fields = append(fields, tf.bind(field))
+	}
+
+	return tagStructField{name: name, fields: fields}
+}
+
+type tagStructField struct {
+	name   string
+	fields []Field
+}
+
+func (f tagStructField) ParseTo(ctx context.Context, errs *Collector) bool {
+	start := errs.Len()
+	ok := true
+	for _, field := range f.fields {
+		if errs.Done(ctx) {
+			return false
+		}
+
+		if !field.ParseTo(ctx, errs) {
+			ok = false
+		}
+	}
+
+	errs.Prefix(start, fieldPath(f.name)...)
+	return ok
+}
+
+// boundField applies a schema to the value it is bound to.
+type boundField[T any] struct {
+	schema Schema[T]
+	value  *T
+}
+
+func (f boundField[T]) ParseTo(ctx context.Context, errs *Collector) bool {
+	return f.schema.ParseValueTo(ctx, f.value, errs)
+}
+
+func tagSchemaOf(t reflect.Type) (*tagSchema, error) {
+	if s, ok := tagSchemas.Load(t); ok {
+		return s.(*tagSchema), nil
+	}
+
+	building := make(map[reflect.Type]*tagSchema)
+	s, err := buildTagSchema(t, building)
+	if err != nil {
+		return nil, err
+	}
+
+	for t, s := range building {
+		tagSchemas.Store(t, s)
+	}
+
+	return s, nil
+}
+
+func buildTagSchema(t reflect.Type, building map[reflect.Type]*tagSchema) (*tagSchema, error) {
+	if s, ok := tagSchemas.Load(t); ok {
+		return s.(*tagSchema), nil
+	}
+	if s, ok := building[t]; ok {
+		return s, nil
+	}
+
+	s := &tagSchema{}
+	building[t] = s
+
+	// fields of the struct itself shadow the promoted fields of embedded structs with the same name
+	names := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		if sf := t.Field(i); sf.IsExported() && !isEmbeddedStruct(sf) {
+			names[tagFieldName(sf)] = true
+		}
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, tagged := sf.Tag.Lookup("validate")
+		if tag == "-" {
+			continue
+		}
+
+		if isEmbeddedStruct(sf) {
+			if tagged {
+				return nil, fmt.Errorf("validator: embedded struct %s.%s can not have a validate tag", t.Name(), sf.Name)
+			}
+
+			embedded := sf.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+
+			es, err := buildTagSchema(embedded, building)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, ef := range es.fields {
+				if !names[ef.name] {
+					s.fields = append(s.fields, tagField{index: append([]int{i}, ef.index...), name: ef.name, bind: ef.bind})
+				}
+			}
+			continue
+		}
+
+		if !sf.IsExported() {
+			if tagged {
+				return nil, fmt.Errorf("validator: field %s.%s has a validate tag but is not exported", t.Name(), sf.Name)
+			}
+			continue
+		}
+
+		opts, err := parseTag(tag)
+		if err != nil {
+			return nil, fmt.Errorf("validator: field %s.%s: %w", t.Name(), sf.Name, err)
+		}
+		opts.name = tagFieldName(sf)
+
+		bind, err := tagBinder(sf.Type, opts, building)
+		if err != nil {
+			return nil, fmt.Errorf("validator: field %s.%s: %w", t.Name(), sf.Name, err)
+		}
+		if bind == nil {
+			continue
+		}
+
+		s.fields = append(s.fields, tagField{index: []int{i}, name: opts.name, bind: bind})
+	}
+
+	return s, nil
+}
+
+// isEmbeddedStruct tells if the fields of 'sf' are promoted to the parent, which is the case for embedded structs
+// without a name in the 'json' tag, as in encoding/json. Embedded structs that are not exported are included.
+func isEmbeddedStruct(sf reflect.StructField) bool {
+	t := sf.Type
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+	return sf.Anonymous && t.Kind() == reflect.Struct && name == ""
+}
+
+func tagFieldName(sf reflect.StructField) string {
+	name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return sf.Name
+	}
+
+	return name
+}
+
+func parseTag(tag string) (tagOptions, error) {
+	var opts tagOptions
+	if tag == "" {
+		return opts, nil
+	}
+
+	for _, part := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch name {
+		case "":
+			return opts, errors.New("empty rule in validate tag")
+		case "required":
+			opts.required = true
+		case "omitempty":
+			opts.omitEmpty = true
+		default:
+			opts.rules = append(opts.rules, tagRule{name: name, param: param})
+		}
+	}
+
+	return opts, nil
+}
+
+// tagBinder returns a function that binds the field schema described by 'opts' to a struct field of type 't'.
+// A nil function is returned for fields that have nothing to validate.
+func tagBinder(t reflect.Type, opts tagOptions, building map[reflect.Type]*tagSchema) (func(reflect.Value) Field, error) {
+	base := t
+	if base.Kind() == reflect.Pointer {
+		base = base.Elem()
+	}
+
+	switch base.Kind() {
+	case reflect.String:
+		s, err := stringTagSchema(opts)
+		return bindTagSchema[string](s, base, opts), err
+	case reflect.Bool:
+		s, err := boolTagSchema(opts)
+		return bindTagSchema[bool](s, base, opts), err
+	case reflect.Int:
+		return numberTagBinder[int](base, opts)
+	case reflect.Int8:
+		return numberTagBinder[int8](base, opts)
+	case reflect.Int16:
+		return numberTagBinder[int16](base, opts)
+	case reflect.Int32:
+		return numberTagBinder[int32](base, opts)
+	case reflect.Int64:
+		return numberTagBinder[int64](base, opts)
+	case reflect.Uint:
+		return numberTagBinder[uint](base, opts)
+	case reflect.Uint8:
+		return numberTagBinder[uint8](base, opts)
+	case reflect.Uint16:
+		return numberTagBinder[uint16](base, opts)
+	case reflect.Uint32:
+		return numberTagBinder[uint32](base, opts)
+	case reflect.Uint64:
+		return numberTagBinder[uint64](base, opts)
+	case reflect.Uintptr:
+		return numberTagBinder[uintptr](base, opts)
+	case reflect.Float32:
+		return numberTagBinder[float32](base, opts)
+	case reflect.Float64:
+		return numberTagBinder[float64](base, opts)
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return lengthTagBinder(base, opts, building)
+	case reflect.Struct:
+		if len(opts.rules) > 0 {
+			return nil, fmt.Errorf("rule %q can not be used on a struct", opts.rules[0].name)
+		}
+
+		s, err := buildTagSchema(base, building)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(value reflect.Value) Field {
+			value, present := tagValue(value, opts)
+			if !present {
+				if !opts.required {
+					return tagStructField{}
+				}
+				return requiredField{name: opts.name}
+			}
+
+			return s.bind(value, opts.name)
+		}, nil
+	}
+
+	if len(opts.rules) > 0 || opts.required {
+		return nil, fmt.Errorf("unsupported type %s", t)
+	}
+
+	return nil, nil
+}
+
+// tagValue dereferences pointer fields and reports if the value should be treated as present.
+func tagValue(value reflect.Value, opts tagOptions) (reflect.Value, bool) {
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return value, false
+		}
+		value = value.Elem()
+	}
+
+	if (opts.required || opts.omitEmpty) && value.IsZero() {
+		return value, false
+	}
+
+	return value, true
+}
+
+func bindTagSchema[T any](s Schema[T], base reflect.Type, opts tagOptions) func(reflect.Value) Field {
+	ptrType := reflect.TypeOf((*T)(nil))
+
+	return func(value reflect.Value) Field {
+		value, present := tagValue(value, opts)
+		if !present {
+			return boundField[T]{schema: s}
+		}
+
+		// named types such as 'type Email string' are converted to a pointer to their underlying type
+		return boundField[T]{schema: s, value: value.Addr().Convert(ptrType).Interface().(*T)}
+	}
+}
+
+type requiredField struct {
+	name string
+}
+
+func (f requiredField) ParseTo(ctx context.Context, errs *Collector) bool {
+	errs.Add(requiredFieldErr(f.name, ""))
+	return false
+}
+
+func stringTagSchema(opts tagOptions) (*StringField, error) {
+	f := String(nil, opts.name)
+	if !opts.required {
+		f.Optional()
+	}
+
+	for _, r := range opts.rules {
+		switch r.name {
+		case "min", "max", "len":
+			n, err := strconv.Atoi(r.param)
+			if err != nil {
+				return nil, fmt.Errorf("rule %q expects an integer, got %q", r.name, r.param)
+			}
+			switch r.name {
+			case "min":
+				f.Min(n)
+			case "max":
+				f.Max(n)
+			default:
+				f.Length(n)
+			}
+		case "email":
+			f.Email()
+		case "uuid":
+			f.UUID()
+		case "url":
+			f.URL()
+		case "alpha":
+			f.Alpha()
+		case "numeric":
+			f.Numeric()
+		case "alphanum":
+			f.AlphaNumeric()
+		case "contains":
+			f.Contains(r.param)
+		case "startswith":
+			f.StartsWith(r.param)
+		case "endswith":
+			f.EndsWith(r.param)
+		case "oneof":
+			f.IsOneOf(strings.Fields(r.param))
+		case "trim":
+			f.TrimSpace()
+		case "lowercase":
+			f.ToLowerCase()
+		default:
+			refine, err := customTagRule[string](r, "string")
+			if err != nil {
+				return nil, err
+			}
+			f.Refine(refine, RefinementData{Code: r.name})
+		}
+	}
+
+	return f, nil
+}
+
+func boolTagSchema(opts tagOptions) (*BoolField, error) {
+	f := Bool(nil, opts.name)
+	if !opts.required {
+		f.Optional()
+	}
+
+	for _, r := range opts.rules {
+		switch r.name {
+		case "is":
+			b, err := strconv.ParseBool(r.param)
+			if err != nil {
+				return nil, fmt.Errorf("rule %q expects a boolean, got %q", r.name, r.param)
+			}
+			f.Is(b)
+		default:
+			refine, err := customTagRule[bool](r, "bool")
+			if err != nil {
+				return nil, err
+			}
+			f.Refine(refine, RefinementData{Code: r.name})
+		}
+	}
+
+	return f, nil
+}
+
+func numberTagBinder[T number](base reflect.Type, opts tagOptions) (func(reflect.Value) Field, error) {
+	f := Number[T](nil, opts.name)
+	if !opts.required {
+		f.Optional()
+	}
+
+	for _, r := range opts.rules {
+		switch r.name {
+		case "min", "max":
+			n, err := parseNumber[T](r.param)
+			if err != nil {
+				return nil, fmt.Errorf("rule %q expects a number, got %q", r.name, r.param)
+			}
+			if r.name == "min" {
+				f.Min(n)
+			} else {
+				f.Max(n)
+			}
+		default:
+			refine, err := customTagRule[T](r, "number")
+			if err != nil {
+				return nil, err
+			}
+			f.Refine(refine, RefinementData{Code: r.name})
+		}
+	}
+
+	return bindTagSchema[T](f, base, opts), nil
+}
+
+func parseNumber[T number](s string) (T, error) {
+	var zero T
+	switch any(zero).(type) {
+	case float32, float64:
+		n, err := strconv.ParseFloat(s, reflect.TypeOf(zero).Bits())
+		return T(n), err
+	case uint, uint8, uint16, uint32, uint64, uintptr:
+		n, err := strconv.ParseUint(s, 10, reflect.TypeOf(zero).Bits())
+		return T(n), err
+	default:
+		n, err := strconv.ParseInt(s, 10, reflect.TypeOf(zero).Bits())
+		return T(n), err
+	}
+}
+
+// lengthTagBinder validates the number of items of slices, arrays and maps, and the items themselves if they are structs.
+// Only the length of the value is needed for the rules, so the items are never copied for them.
+func lengthTagBinder(base reflect.Type, opts tagOptions, building map[reflect.Type]*tagSchema) (func(reflect.Value) Field, error) {
+	f := Slice[struct{}](nil, opts.name)
+	if !opts.required {
+		f.Optional()
+	}
+
+	var custom []tagRuleField
+	for _, r := range opts.rules {
+		switch r.name {
+		case "min", "max", "len":
+			n, err := strconv.Atoi(r.param)
+			if err != nil {
+				return nil, fmt.Errorf("rule %q expects an integer, got %q", r.name, r.param)
+			}
+			switch r.name {
+			case "min":
+				f.Min(n)
+			case "max":
+				f.Max(n)
+			default:
+				f.Length(n)
+			}
+		default:
+			rule, err := lookupTagRule(r, base.Kind().String())
+			if err != nil {
+				return nil, err
+			}
+			custom = append(custom, tagRuleField{name: opts.name, rule: rule, tagRule: r})
+		}
+	}
+
+	var itemSchema *tagSchema
+	if elem := base.Elem(); elem.Kind() == reflect.Struct || (elem.Kind() == reflect.Pointer && elem.Elem().Kind() == reflect.Struct) {
+		if elem.Kind() == reflect.Pointer {
+			elem = elem.Elem()
+		}
+
+		s, err := buildTagSchema(elem, building)
+		if err != nil {
+			return nil, err
+		}
+		itemSchema = s
+	}
+
+	return func(value reflect.Value) Field {
+		value, present := tagValue(value, opts)
+		if !present {
+			return boundField[[]struct{}]{schema: f}
+		}
+
+		items := make([]struct{}, value.Len())
+		fields := []Field{boundField[[]struct{}]{schema: f, value: &items}}
+		for _, c := range custom {
+			c.value = value.Interface()
+			fields = append(fields, c)
+		}
+		if itemSchema != nil {
+			fields = append(fields, tagItemsField{name: opts.name, schema: itemSchema, value: value})
+		}
+
+		return tagStructField{fields: fields}
+	}, nil
+}
+
+// tagItemsField validates the structs held by a slice, array or map.
+// Errors of an item are reported with its index or key, for example 'addresses[0].city'.
+type tagItemsField struct {
+	name   string
+	schema *tagSchema
+	value  reflect.Value
+}
+
+func (f tagItemsField) ParseTo(ctx context.Context, errs *Collector) bool {
+	ok := true
+	if f.value.Kind() != reflect.Map {
+		for i := 0; i < f.value.Len(); i++ {
+			if errs.Done(ctx) {
+				return false
+			}
+
+			if !f.parseItem(ctx, f.value.Index(i), PathSegment{Kind: SegmentIndex, Index: i}, errs) {
+				ok = false
+			}
+		}
+
+		return ok
+	}
+
+	// keys are sorted so that errors are stable, see MapField
+	keys := f.value.MapKeys()
+	slices.SortFunc(keys, func(a, b reflect.Value) int {
+		return compareKeys(a.Interface(), b.Interface())
+	})
+
+	for _, key := range keys {
+		if errs.Done(ctx) {
+			return false
+		}
+
+		entry := f.value.MapIndex(key)
+		if !entry.IsValid() {
+			// keys that are not equal to themselves, like NaN, can't be looked up
+			continue
+		}
+
+		// map entries are not addressable, so the item is parsed on a copy that is written back with its transformations
+		item := reflect.New(entry.Type()).Elem()
+		item.Set(entry)
+		if !f.parseItem(ctx, item, PathSegment{Kind: SegmentKey, Key: key.Interface()}, errs) {
+			ok = false
+		}
+		f.value.SetMapIndex(key, item)
+	}
+
+	return ok
+}
+
+// parseItem validates a single item, skipping nil pointers.
+func (f tagItemsField) parseItem(ctx context.Context, item reflect.Value, segment PathSegment, errs *Collector) bool {
+	item, present := tagValue(item, tagOptions{})
+	if !present {
+		return true
+	}
+
+	start := errs.Len()
+	ok := f.schema.bind(item, "").ParseTo(ctx, errs)
+	errs.Prefix(start, append(fieldPath(f.name), segment)...)
+	return ok
+}
+
+// tagRuleField runs a custom rule against values that have no field type of their own.
+type tagRuleField struct {
+	name    string
+	rule    TagRule
+	tagRule tagRule
+	value   any
+}
+
+func (f tagRuleField) ParseTo(ctx context.Context, errs *Collector) bool {
+	if err := f.rule(f.value, f.tagRule.param); err != nil {
+		errs.Add(newError(f.name, err.Error(), f.tagRule.name))
+		return false
+	}
+
+	return true
+}
+
+func lookupTagRule(r tagRule, kind string) (TagRule, error) {
+	rule, ok := tagRules.Load(r.name)
+	if !ok {
+		if builtinRules[r.name] {
+			return nil, fmt.Errorf("rule %q can not be used on a %s", r.name, kind)
+		}
+		return nil, fmt.Errorf("unknown rule %q", r.name)
+	}
+
+	return rule.(TagRule), nil
+}
+
+func customTagRule[T any](r tagRule, kind string) (func(T) error, error) {
+	rule, err := lookupTagRule(r, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(value T) error {
+		return rule(value, r.param)
+	}, nil
+}
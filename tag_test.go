@@ -0,0 +1,275 @@
+package validator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateStruct(t *testing.T) {
+
+	type Address struct {
+		City string `json:"city" validate:"required,min=2"`
+		Zip  string `json:"zip" validate:"len=5,numeric"`
+	}
+
+	type User struct {
+		Name    string   `json:"name" validate:"required,trim,min=3,max=50"`
+		Email   string   `json:"email" validate:"lowercase,email"`
+		Age     int      `json:"age" validate:"min=18,max=130"`
+		Port    uint16   `json:"port" validate:"max=65535"`
+		Score   *float64 `json:"score" validate:"max=10"`
+		Tags    []string `json:"tags" validate:"max=2"`
+		Admin   bool     `json:"admin" validate:"is=false"`
+		Address Address  `json:"address"`
+	}
+
+	goodInput := User{
+		Name:    "  aaditya  ",
+		Email:   "AADITYA220055@GMAIL.COM",
+		Age:     21,
+		Tags:    []string{"go"},
+		Address: Address{City: "Delhi", Zip: "11001"},
+	}
+
+	errs, err := ValidateStruct(&goodInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(errs) > 0 {
+		t.Errorf("expected no error, got %v", errs)
+	}
+
+	if goodInput.Name != "aaditya" || goodInput.Email != "aaditya220055@gmail.com" {
+		t.Error("input not transformed properly")
+	}
+
+	score := 11.0
+	badInput := User{
+		Name:    "",
+		Email:   "aaditya",
+		Age:     17,
+		Score:   &score,
+		Tags:    []string{"go", "rust", "zig"},
+		Admin:   true,
+		Address: Address{Zip: "1100a"},
+	}
+
+	errs, err = ValidateStruct(&badInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	codes := make(map[string]string)
+	for _, e := range errs {
+		codes[e.Field] = e.Code
+	}
+
+	expected := map[string]string{
+		"name":         CodeRequired,
+		"email":        CodeEmail,
+		"age":          CodeMin,
+		"score":        CodeMax,
+		"tags":         CodeMax,
+		"admin":        CodeIs,
+		"address.city": CodeRequired,
+		"address.zip":  CodeNumeric,
+	}
+
+	for field, code := range expected {
+		if codes[field] != code {
+			t.Errorf("expected %s error for %s, got %q", code, field, codes[field])
+		}
+	}
+}
+
+func TestValidateStructOptionalPointers(t *testing.T) {
+
+	type Profile struct {
+		Bio string `validate:"max=10"`
+	}
+
+	type User struct {
+		Nickname *string  `validate:"min=3"`
+		Profile  *Profile `validate:"required"`
+	}
+
+	errs, err := ValidateStruct(&User{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(errs) != 1 || errs[0].Field != "Profile" || errs[0].Code != CodeRequired {
+		t.Errorf("expected only a required error for Profile, got %v", errs)
+	}
+}
+
+func TestValidateStructUnknownRule(t *testing.T) {
+
+	type User struct {
+		Name string `validate:"required,shout"`
+	}
+
+	_, err := ValidateStruct(&User{Name: "aaditya"})
+	if err == nil || !strings.Contains(err.Error(), `unknown rule "shout"`) {
+		t.Errorf("expected unknown rule error, got %v", err)
+	}
+
+	_, err = ValidateStruct(User{})
+	if err == nil {
+		t.Error("expected error for non pointer value")
+	}
+}
+
+func TestValidateStructCustomRule(t *testing.T) {
+
+	type User struct {
+		Name string `validate:"palindrome"`
+	}
+
+	t.Cleanup(func() {
+		tagRules.Delete("palindrome")
+		tagSchemas.Range(func(key, _ any) bool {
+			tagSchemas.Delete(key)
+			return true
+		})
+	})
+
+	RegisterTagRule("palindrome", func(value any, param string) error {
+		s := value.(string)
+		for i := 0; i < len(s)/2; i++ {
+			if s[i] != s[len(s)-1-i] {
+				return errors.New("not a palindrome")
+			}
+		}
+
+		return nil
+	})
+
+	errs, err := ValidateStruct(&User{Name: "madam"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(errs) > 0 {
+		t.Error("expected no error")
+	}
+
+	errs, _ = ValidateStruct(&User{Name: "aaditya"})
+	if len(errs) == 0 || errs[0].Code != "palindrome" {
+		t.Errorf("expected palindrome error, got %v", errs)
+	}
+}
+
+func TestValidateStructItems(t *testing.T) {
+
+	type Address struct {
+		City string `json:"city" validate:"required,min=2,trim"`
+	}
+
+	type User struct {
+		Addresses []Address           `json:"addresses" validate:"min=1"`
+		Offices   [2]*Address         `json:"offices"`
+		Homes     map[string]Address  `json:"homes"`
+		Previous  map[string]*Address `json:"previous"`
+	}
+
+	user := User{
+		Addresses: []Address{{City: " Delhi "}, {}},
+		Offices:   [2]*Address{nil, {City: "a"}},
+		Homes:     map[string]Address{"main": {City: " Pune "}, "summer": {City: "x"}},
+		Previous:  map[string]*Address{"old": {}},
+	}
+
+	errs, err := ValidateStruct(&user)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"addresses[1].city", "offices[1].city", `homes["summer"].city`, `previous["old"].city`}
+	if len(errs) != len(expected) {
+		t.Fatalf("expected %d errors, got %v", len(expected), errs)
+	}
+
+	for i, field := range expected {
+		if errs[i].Field != field {
+			t.Errorf("expected an error for %s, got %v", field, errs[i])
+		}
+	}
+
+	if user.Addresses[0].City != "Delhi" || user.Homes["main"].City != "Pune" {
+		t.Errorf("expected the items to be transformed, got %+v", user)
+	}
+}
+
+func TestValidateStructEmbedded(t *testing.T) {
+
+	type base struct {
+		ID   string `json:"id" validate:"required,uuid"`
+		Name string `json:"name" validate:"required"`
+	}
+
+	type Audit struct {
+		CreatedBy string `json:"created_by" validate:"required,trim"`
+	}
+
+	type Owner struct {
+		Email string `json:"email" validate:"email"`
+	}
+
+	type Document struct {
+		base
+		*Audit
+		*Owner
+		Name string `json:"name" validate:"min=3"`
+	}
+
+	doc := Document{base: base{ID: "x"}, Audit: &Audit{}, Name: "ab"}
+	errs, err := ValidateStruct(&doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"id", "created_by", "name"}
+	if len(errs) != len(expected) {
+		t.Fatalf("expected %d errors, got %v", len(expected), errs)
+	}
+
+	for i, field := range expected {
+		if errs[i].Field != field {
+			t.Errorf("expected an error for %s, got %v", field, errs[i])
+		}
+	}
+
+	if errs[2].Code != CodeMin {
+		t.Errorf("expected the name of Document to shadow the name of base, got %v", errs[2])
+	}
+
+	type Tagged struct {
+		Audit `validate:"required"`
+	}
+
+	if _, err := ValidateStruct(&Tagged{}); err == nil {
+		t.Error("expected an error for the validate tag of an embedded struct")
+	}
+}
+
+func TestValidateStructRecursiveType(t *testing.T) {
+
+	type Node struct {
+		Name string `validate:"required"`
+		Next *Node
+	}
+
+	list := Node{Name: "a", Next: &Node{Name: "b", Next: &Node{}}}
+
+	errs, err := ValidateStruct(&list)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(errs) != 1 {
+		t.Errorf("expected 1 error, got %v", errs)
+	}
+}
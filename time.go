@@ -0,0 +1,599 @@
+package validator
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// Clock tells the current time to the rules that depend on it, such as InFuture and InPast.
+// Replace it with a fixed clock to keep tests deterministic.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc adapts a function to the Clock interface
+type ClockFunc func() time.Time
+
+// Now calls the function
+func (fn ClockFunc) Now() time.Time {
+	return fn()
+}
+
+type TimeField struct {
+	fieldCore[time.Time]
+	clock Clock
+}
+
+func (f *TimeField) now() time.Time {
+	if f.clock == nil {
+		return time.Now()
+	}
+
+	return f.clock.Now()
+}
+
+func (f *TimeField) ParseTo(ctx context.Context, errs *Collector) bool {
+	value, ok, done := f.boundValue(errs)
+	if done {
+		return ok
+	}
+
+	return f.ParseValueTo(ctx, value, errs)
+}
+
+func (f *TimeField) ParseValueTo(ctx context.Context, value *time.Time, errs *Collector) bool {
+	return f.parse(ctx, value, errs)
+}
+
+// AbortEarly stops the parsing of the field on the first error
+func (f *TimeField) AbortEarly() *TimeField {
+	f.abortEarly = true
+	return f
+}
+
+// Optional makes the field optional
+func (f *TimeField) Optional() *TimeField {
+	f.optional = true
+	return f
+}
+
+// Sets a custom error message if the field is missing
+func (f *TimeField) RequiredError(message string) *TimeField {
+	f.requiredError = message
+	return f
+}
+
+// Default sets the value of the field to 'value' when it is missing, before the rules run.
+// A value is missing when its Patch is absent or null, or when it is empty with EmptyAsMissing, see FromPatch.
+// A nil pointer can't hold the default, so it is still required unless the field is Optional.
+// A blank string of a Coerce field is missing, and the default is stored in its target.
+func (f *TimeField) Default(value time.Time) *TimeField {
+	return f.DefaultFunc(func() time.Time { return value })
+}
+
+// DefaultFunc is like Default, but calls 'fn' for the default every time the value is missing.
+func (f *TimeField) DefaultFunc(fn func() time.Time) *TimeField {
+	f.defaultValue = fn
+	return f
+}
+
+// Nullable accepts null values, a nil pointer or a null Patch, and skips the rules for them.
+// Without it, null counts as missing.
+func (f *TimeField) Nullable() *TimeField {
+	f.nullable = true
+	return f
+}
+
+// EmptyAsMissing treats the zero value of the field, or an empty slice or map, as missing.
+// It then gets the default, or is reported as required unless the field is Optional.
+func (f *TimeField) EmptyAsMissing() *TimeField {
+	f.emptyAsMissing = true
+	return f
+}
+
+// Before checks if the field value is before the provided time
+func (f *TimeField) Before(value time.Time, message ...string) *TimeField {
+	code := CodeBefore
+
+	validator := func(fv time.Time) error {
+		if !fv.Before(value) {
+			return ruleMessage(message, CodeBefore, f.name, map[string]any{"before": value})
+		}
+
+		return nil
+	}
+
+	f.addValidation(validator, code)
+	return f
+}
+
+// After checks if the field value is after the provided time
+func (f *TimeField) After(value time.Time, message ...string) *TimeField {
+	code := CodeAfter
+
+	validator := func(fv time.Time) error {
+		if !fv.After(value) {
+			return ruleMessage(message, CodeAfter, f.name, map[string]any{"after": value})
+		}
+
+		return nil
+	}
+
+	f.addValidation(validator, code)
+	return f
+}
+
+// Between checks if the field value lies between lo and hi.
+// bounds decides if lo and hi are themselves valid values.
+func (f *TimeField) Between(lo, hi time.Time, bounds Bounds, message ...string) *TimeField {
+	code := CodeBetween
+
+	validator := func(fv time.Time) error {
+		if !inTimeBounds(fv, lo, hi, bounds) {
+			return ruleMessage(message, CodeBetween, f.name, map[string]any{"min": lo, "max": hi, "bounds": bounds})
+		}
+
+		return nil
+	}
+
+	f.addValidation(validator, code)
+	return f
+}
+
+// inTimeBounds is inBounds for times. Times are compared with Compare, since UnixNano only covers the years 1678 to 2262.
+func inTimeBounds(value, lo, hi time.Time, bounds Bounds) bool {
+	aboveLo := value.Compare(lo) >= 0
+	if bounds == Exclusive || bounds == ExclusiveLo {
+		aboveLo = value.Compare(lo) > 0
+	}
+
+	belowHi := value.Compare(hi) <= 0
+	if bounds == Exclusive || bounds == ExclusiveHi {
+		belowHi = value.Compare(hi) < 0
+	}
+
+	return aboveLo && belowHi
+}
+
+// InFuture checks if the field value is after the current time of the field's clock
+func (f *TimeField) InFuture(message ...string) *TimeField {
+	code := CodeInFuture
+
+	validator := func(fv time.Time) error {
+		if !fv.After(f.now()) {
+			return ruleMessage(message, CodeInFuture, f.name, nil)
+		}
+
+		return nil
+	}
+
+	f.addValidation(validator, code)
+	return f
+}
+
+// InPast checks if the field value is before the current time of the field's clock
+func (f *TimeField) InPast(message ...string) *TimeField {
+	code := CodeInPast
+
+	validator := func(fv time.Time) error {
+		if !fv.Before(f.now()) {
+			return ruleMessage(message, CodeInPast, f.name, nil)
+		}
+
+		return nil
+	}
+
+	f.addValidation(validator, code)
+	return f
+}
+
+// Weekday checks if the field value falls on one of the provided days
+func (f *TimeField) Weekday(days []time.Weekday, message ...string) *TimeField {
+	code := CodeWeekday
+
+	validator := func(fv time.Time) error {
+		if !slices.Contains(days, fv.Weekday()) {
+			return ruleMessage(message, CodeWeekday, f.name, map[string]any{"weekdays": days})
+		}
+
+		return nil
+	}
+
+	f.addValidation(validator, code)
+	return f
+}
+
+// NotZero checks if the field value is not the zero time
+func (f *TimeField) NotZero(message ...string) *TimeField {
+	code := CodeNotZero
+
+	validator := func(fv time.Time) error {
+		if fv.IsZero() {
+			return ruleMessage(message, CodeNotZero, f.name, nil)
+		}
+
+		return nil
+	}
+
+	f.addValidation(validator, code)
+	return f
+}
+
+// Truncate rounds the field value down to a multiple of d since the zero time
+func (f *TimeField) Truncate(d time.Duration) *TimeField {
+	fn := func(value time.Time) time.Time {
+		return value.Truncate(d)
+	}
+
+	f.addTransformer(fn)
+	return f
+}
+
+// InLocation converts the field value to the provided location
+func (f *TimeField) InLocation(loc *time.Location) *TimeField {
+	fn := func(value time.Time) time.Time {
+		return value.In(loc)
+	}
+
+	f.addTransformer(fn)
+	return f
+}
+
+// Clock sets the clock used by InFuture and InPast. The system clock is used by default.
+func (f *TimeField) Clock(clock Clock) *TimeField {
+	f.clock = clock
+	return f
+}
+
+// When adds the rules of 'then' to the field, but only applies them if 'cond' returns true when the field is parsed.
+// 'then' receives a new field with the same name to add the rules to.
+func (f *TimeField) When(cond func() bool, then func(*TimeField)) *TimeField {
+	sub := Time(nil, f.name)
+	sub.clock = ClockFunc(f.now)
+	then(sub)
+
+	f.dependent = true
+	f.addNested(func(ctx context.Context, value *time.Time, errs *Collector) bool {
+		return !cond() || sub.ParseValueTo(ctx, value, errs)
+	})
+	return f
+}
+
+// Unless is like When, but applies the rules of 'then' only if 'cond' returns false
+func (f *TimeField) Unless(cond func() bool, then func(*TimeField)) *TimeField {
+	return f.When(func() bool { return !cond() }, then)
+}
+
+// RequiredIf makes the field required if 'cond' returns true when the field is parsed, and optional otherwise.
+// Nil and zero values count as missing, and the rules of a missing optional field are skipped.
+func (f *TimeField) RequiredIf(cond func() bool) *TimeField {
+	f.presence.requireIf(cond)
+	return f
+}
+
+// RequiredUnless makes the field required if 'cond' returns false, see RequiredIf
+func (f *TimeField) RequiredUnless(cond func() bool) *TimeField {
+	return f.RequiredIf(func() bool { return !cond() })
+}
+
+// RequiredWith makes the field required if any of the values pointed to by 'others' is present, see RequiredIf
+func (f *TimeField) RequiredWith(others ...any) *TimeField {
+	return f.RequiredIf(func() bool { return anyPresent(others) })
+}
+
+// ExcludedWith reports the field with CodeExcluded if it is present while any of the values pointed to by 'others' is.
+// The rules of the field are skipped while it is excluded.
+func (f *TimeField) ExcludedWith(others ...any) *TimeField {
+	f.presence.excludeIf(func() bool { return anyPresent(others) })
+	return f
+}
+
+// Refine lets you provide custom validation logic
+func (f *TimeField) Refine(fn func(time.Time) error, refinementData ...RefinementData) *TimeField {
+	return f.RefineCtx(func(_ context.Context, value time.Time) error { return fn(value) }, refinementData...)
+}
+
+// RefineCtx is like Refine, but 'fn' also receives the context passed to ParseCtx.
+// An error returned after the context is done is not reported as a refinement error.
+func (f *TimeField) RefineCtx(fn func(context.Context, time.Time) error, refinementData ...RefinementData) *TimeField {
+	var newRefinementData RefinementData
+	if len(refinementData) > 0 {
+		newRefinementData = refinementData[0]
+	}
+
+	f.addRefinement(fn, newRefinementData)
+	return f
+}
+
+// Transform "transforms" the field value.
+func (f *TimeField) Transform(fn func(time.Time) time.Time) *TimeField {
+	f.addTransformer(fn)
+	return f
+}
+
+// Parse parses the field and returns a slice of Error.
+func (f *TimeField) Parse(opts ...ParseOption) []Error {
+	return f.ParseCtx(context.Background(), opts...)
+}
+
+// ParseCtx parses the field like Parse and passes 'ctx' to the refinements added with RefineCtx.
+// When 'ctx' is done the parse stops and a CodeCanceled or CodeDeadlineExceeded error is reported.
+func (f *TimeField) ParseCtx(ctx context.Context, opts ...ParseOption) []Error {
+	errs := newCollector(opts)
+	f.ParseTo(ctx, &errs)
+	return parseResult(ctx, f.name, &errs, opts)
+}
+
+// ParseValue parses the provided value instead of the bound one and returns a slice of Error.
+func (f *TimeField) ParseValue(value *time.Time, opts ...ParseOption) []Error {
+	return f.ParseValueCtx(context.Background(), value, opts...)
+}
+
+// ParseValueCtx parses the provided value like ParseValue, with the context handling of ParseCtx.
+func (f *TimeField) ParseValueCtx(ctx context.Context, value *time.Time, opts ...ParseOption) []Error {
+	errs := newCollector(opts)
+	f.ParseValueTo(ctx, value, &errs)
+	return parseResult(ctx, f.name, &errs, opts)
+}
+
+// Validate parses the field and returns the errors as Errors, or nil if the field is valid.
+func (f *TimeField) Validate(opts ...ParseOption) error {
+	return asError(f.Parse(opts...))
+}
+
+// ValidateValue parses the provided value like ParseValue and returns the errors as Errors, or nil if the value is valid.
+func (f *TimeField) ValidateValue(value *time.Time, opts ...ParseOption) error {
+	return asError(f.ParseValue(value, opts...))
+}
+
+// Time takes a pointer to a time.Time and a variadic argument 'name'.
+// Even if multiple values are passed for 'name', only the first value will be considered.
+// Pass a nil pointer to declare a reusable schema and apply it with ParseValue.
+func Time(value *time.Time, name ...string) *TimeField {
+	return &TimeField{fieldCore: newFieldCore(value, name)}
+}
+
+type DurationField struct {
+	fieldCore[time.Duration]
+}
+
+func (f *DurationField) ParseTo(ctx context.Context, errs *Collector) bool {
+	value, ok, done := f.boundValue(errs)
+	if done {
+		return ok
+	}
+
+	return f.ParseValueTo(ctx, value, errs)
+}
+
+func (f *DurationField) ParseValueTo(ctx context.Context, value *time.Duration, errs *Collector) bool {
+	return f.parse(ctx, value, errs)
+}
+
+// AbortEarly stops the parsing of the field on the first error
+func (f *DurationField) AbortEarly() *DurationField {
+	f.abortEarly = true
+	return f
+}
+
+// Optional makes the field optional
+func (f *DurationField) Optional() *DurationField {
+	f.optional = true
+	return f
+}
+
+// Sets a custom error message if the field is missing
+func (f *DurationField) RequiredError(message string) *DurationField {
+	f.requiredError = message
+	return f
+}
+
+// Default sets the value of the field to 'value' when it is missing, before the rules run.
+// A value is missing when its Patch is absent or null, or when it is empty with EmptyAsMissing, see FromPatch.
+// A nil pointer can't hold the default, so it is still required unless the field is Optional.
+func (f *DurationField) Default(value time.Duration) *DurationField {
+	return f.DefaultFunc(func() time.Duration { return value })
+}
+
+// DefaultFunc is like Default, but calls 'fn' for the default every time the value is missing.
+func (f *DurationField) DefaultFunc(fn func() time.Duration) *DurationField {
+	f.defaultValue = fn
+	return f
+}
+
+// Nullable accepts null values, a nil pointer or a null Patch, and skips the rules for them.
+// Without it, null counts as missing.
+func (f *DurationField) Nullable() *DurationField {
+	f.nullable = true
+	return f
+}
+
+// EmptyAsMissing treats the zero value of the field, or an empty slice or map, as missing.
+// It then gets the default, or is reported as required unless the field is Optional.
+func (f *DurationField) EmptyAsMissing() *DurationField {
+	f.emptyAsMissing = true
+	return f
+}
+
+// Min sets the minimum value for the field.
+func (f *DurationField) Min(value time.Duration, message ...string) *DurationField {
+	code := CodeMin
+
+	validator := func(fv time.Duration) error {
+		if fv < value {
+			return ruleMessage(message, CodeMin, f.name, map[string]any{"min": value})
+		}
+
+		return nil
+	}
+
+	f.addValidation(validator, code)
+	return f
+}
+
+// Max sets the maximum value for the field.
+func (f *DurationField) Max(value time.Duration, message ...string) *DurationField {
+	code := CodeMax
+
+	validator := func(fv time.Duration) error {
+		if fv > value {
+			return ruleMessage(message, CodeMax, f.name, map[string]any{"max": value})
+		}
+
+		return nil
+	}
+
+	f.addValidation(validator, code)
+	return f
+}
+
+// Between checks if the field value lies between lo and hi.
+// bounds decides if lo and hi are themselves valid values.
+func (f *DurationField) Between(lo, hi time.Duration, bounds Bounds, message ...string) *DurationField {
+	code := CodeBetween
+
+	validator := func(fv time.Duration) error {
+		if !inBounds(int64(fv), int64(lo), int64(hi), bounds) {
+			return ruleMessage(message, CodeBetween, f.name, map[string]any{"min": lo, "max": hi, "bounds": bounds})
+		}
+
+		return nil
+	}
+
+	f.addValidation(validator, code)
+	return f
+}
+
+// NotZero checks if the field value is not zero
+func (f *DurationField) NotZero(message ...string) *DurationField {
+	code := CodeNotZero
+
+	validator := func(fv time.Duration) error {
+		if fv == 0 {
+			return ruleMessage(message, CodeNotZero, f.name, nil)
+		}
+
+		return nil
+	}
+
+	f.addValidation(validator, code)
+	return f
+}
+
+// Truncate rounds the field value toward zero to a multiple of m
+func (f *DurationField) Truncate(m time.Duration) *DurationField {
+	fn := func(value time.Duration) time.Duration {
+		return value.Truncate(m)
+	}
+
+	f.addTransformer(fn)
+	return f
+}
+
+// When adds the rules of 'then' to the field, but only applies them if 'cond' returns true when the field is parsed.
+// 'then' receives a new field with the same name to add the rules to.
+func (f *DurationField) When(cond func() bool, then func(*DurationField)) *DurationField {
+	sub := Duration(nil, f.name)
+	then(sub)
+
+	f.dependent = true
+	f.addNested(func(ctx context.Context, value *time.Duration, errs *Collector) bool {
+		return !cond() || sub.ParseValueTo(ctx, value, errs)
+	})
+	return f
+}
+
+// Unless is like When, but applies the rules of 'then' only if 'cond' returns false
+func (f *DurationField) Unless(cond func() bool, then func(*DurationField)) *DurationField {
+	return f.When(func() bool { return !cond() }, then)
+}
+
+// RequiredIf makes the field required if 'cond' returns true when the field is parsed, and optional otherwise.
+// Nil and zero values count as missing, and the rules of a missing optional field are skipped.
+func (f *DurationField) RequiredIf(cond func() bool) *DurationField {
+	f.presence.requireIf(cond)
+	return f
+}
+
+// RequiredUnless makes the field required if 'cond' returns false, see RequiredIf
+func (f *DurationField) RequiredUnless(cond func() bool) *DurationField {
+	return f.RequiredIf(func() bool { return !cond() })
+}
+
+// RequiredWith makes the field required if any of the values pointed to by 'others' is present, see RequiredIf
+func (f *DurationField) RequiredWith(others ...any) *DurationField {
+	return f.RequiredIf(func() bool { return anyPresent(others) })
+}
+
+// ExcludedWith reports the field with CodeExcluded if it is present while any of the values pointed to by 'others' is.
+// The rules of the field are skipped while it is excluded.
+func (f *DurationField) ExcludedWith(others ...any) *DurationField {
+	f.presence.excludeIf(func() bool { return anyPresent(others) })
+	return f
+}
+
+// Refine lets you provide custom validation logic
+func (f *DurationField) Refine(fn func(time.Duration) error, refinementData ...RefinementData) *DurationField {
+	return f.RefineCtx(func(_ context.Context, value time.Duration) error { return fn(value) }, refinementData...)
+}
+
+// RefineCtx is like Refine, but 'fn' also receives the context passed to ParseCtx.
+// An error returned after the context is done is not reported as a refinement error.
+func (f *DurationField) RefineCtx(fn func(context.Context, time.Duration) error, refinementData ...RefinementData) *DurationField {
+	var newRefinementData RefinementData
+	if len(refinementData) > 0 {
+		newRefinementData = refinementData[0]
+	}
+
+	f.addRefinement(fn, newRefinementData)
+	return f
+}
+
+// Transform "transforms" the field value.
+func (f *DurationField) Transform(fn func(time.Duration) time.Duration) *DurationField {
+	f.addTransformer(fn)
+	return f
+}
+
+// Parse parses the field and returns a slice of Error.
+func (f *DurationField) Parse(opts ...ParseOption) []Error {
+	return f.ParseCtx(context.Background(), opts...)
+}
+
+// ParseCtx parses the field like Parse and passes 'ctx' to the refinements added with RefineCtx.
+// When 'ctx' is done the parse stops and a CodeCanceled or CodeDeadlineExceeded error is reported.
+func (f *DurationField) ParseCtx(ctx context.Context, opts ...ParseOption) []Error {
+	errs := newCollector(opts)
+	f.ParseTo(ctx, &errs)
+	return parseResult(ctx, f.name, &errs, opts)
+}
+
+// ParseValue parses the provided value instead of the bound one and returns a slice of Error.
+func (f *DurationField) ParseValue(value *time.Duration, opts ...ParseOption) []Error {
+	return f.ParseValueCtx(context.Background(), value, opts...)
+}
+
+// ParseValueCtx parses the provided value like ParseValue, with the context handling of ParseCtx.
+func (f *DurationField) ParseValueCtx(ctx context.Context, value *time.Duration, opts ...ParseOption) []Error {
+	errs := newCollector(opts)
+	f.ParseValueTo(ctx, value, &errs)
+	return parseResult(ctx, f.name, &errs, opts)
+}
+
+// Validate parses the field and returns the errors as Errors, or nil if the field is valid.
+func (f *DurationField) Validate(opts ...ParseOption) error {
+	return asError(f.Parse(opts...))
+}
+
+// ValidateValue parses the provided value like ParseValue and returns the errors as Errors, or nil if the value is valid.
+func (f *DurationField) ValidateValue(value *time.Duration, opts ...ParseOption) error {
+	return asError(f.ParseValue(value, opts...))
+}
+
+// Duration takes a pointer to a time.Duration and a variadic argument 'name'.
+// Even if multiple values are passed for 'name', only the first value will be considered.
+// Pass a nil pointer to declare a reusable schema and apply it with ParseValue.
+func Duration(value *time.Duration, name ...string) *DurationField {
+	return &DurationField{fieldCore: newFieldCore(value, name)}
+}
@@ -0,0 +1,158 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var fixedClock = ClockFunc(func() time.Time {
+	return time.Date(2024, time.March, 15, 12, 0, 0, 0, time.UTC)
+})
+
+func TestTimeBeforeAfter(t *testing.T) {
+	limit := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	before := limit.Add(-time.Hour)
+	after := limit.Add(time.Hour)
+
+	if errs := Time(&before).Before(limit).Parse(); len(errs) > 0 {
+		t.Error("expected no error")
+	}
+
+	if errs := Time(&after).Before(limit).Parse(); len(errs) == 0 || errs[0].Code != CodeBefore {
+		t.Error("expected before error")
+	}
+
+	if errs := Time(&after).After(limit).Parse(); len(errs) > 0 {
+		t.Error("expected no error")
+	}
+
+	if errs := Time(&limit).After(limit).Parse(); len(errs) == 0 || errs[0].Code != CodeAfter {
+		t.Error("expected after error")
+	}
+}
+
+func TestTimeBetween(t *testing.T) {
+	lo := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	hi := time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC)
+	input := lo
+
+	if errs := Time(&input).Between(lo, hi, Inclusive).Parse(); len(errs) > 0 {
+		t.Error("expected no error")
+	}
+
+	if errs := Time(&input).Between(lo, hi, Exclusive).Parse(); len(errs) == 0 || errs[0].Code != CodeBetween {
+		t.Error("expected between error")
+	}
+
+	// bounds outside of the range of UnixNano
+	lo = time.Date(1000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	hi = time.Date(3000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	input = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	if errs := Time(&input).Between(lo, hi, Exclusive).Parse(); len(errs) > 0 {
+		t.Errorf("expected no error, got %v", errs)
+	}
+
+	input = time.Date(3001, time.January, 1, 0, 0, 0, 0, time.UTC)
+	if errs := Time(&input).Between(lo, hi, Inclusive).Parse(); len(errs) == 0 || errs[0].Code != CodeBetween {
+		t.Error("expected between error")
+	}
+}
+
+func TestTimeClock(t *testing.T) {
+	future := fixedClock.Now().Add(time.Minute)
+	past := fixedClock.Now().Add(-time.Minute)
+
+	if errs := Time(&future).Clock(fixedClock).InFuture().Parse(); len(errs) > 0 {
+		t.Error("expected no error")
+	}
+
+	if errs := Time(&past).Clock(fixedClock).InFuture().Parse(); len(errs) == 0 || errs[0].Code != CodeInFuture {
+		t.Error("expected in-future error")
+	}
+
+	if errs := Time(&past).Clock(fixedClock).InPast().Parse(); len(errs) > 0 {
+		t.Error("expected no error")
+	}
+
+	if errs := Time(&future).Clock(fixedClock).InPast().Parse(); len(errs) == 0 || errs[0].Code != CodeInPast {
+		t.Error("expected in-past error")
+	}
+}
+
+func TestTimeWeekdayAndNotZero(t *testing.T) {
+	friday := fixedClock.Now()
+	var zero time.Time
+	weekend := []time.Weekday{time.Saturday, time.Sunday}
+
+	if errs := Time(&friday).Weekday(weekend).Parse(); len(errs) == 0 || errs[0].Code != CodeWeekday {
+		t.Error("expected weekday error")
+	}
+
+	if errs := Time(&friday).Weekday([]time.Weekday{time.Friday}).NotZero().Parse(); len(errs) > 0 {
+		t.Error("expected no error")
+	}
+
+	if errs := Time(&zero).NotZero().Parse(); len(errs) == 0 || errs[0].Code != CodeNotZero {
+		t.Error("expected not-zero error")
+	}
+}
+
+func TestTimeTransformBeforeValidation(t *testing.T) {
+	kolkata := time.FixedZone("IST", 5*60*60+30*60)
+	input := time.Date(2024, time.March, 15, 23, 59, 30, 0, time.UTC)
+
+	errs := Time(&input).
+		InLocation(kolkata).
+		Weekday([]time.Weekday{time.Saturday}).
+		Truncate(time.Minute).
+		Refine(func(v time.Time) error {
+			if v.Second() != 0 {
+				return errors.New("not truncated")
+			}
+
+			return nil
+		}).
+		Parse()
+
+	if len(errs) > 0 {
+		t.Errorf("expected no error, got %v", errs)
+	}
+
+	if input.Location() != kolkata {
+		t.Error("input not transformed properly")
+	}
+}
+
+func TestTimeOptionalNilPointer(t *testing.T) {
+	var input *time.Time
+
+	if errs := Time(input).Optional().InFuture().Parse(); len(errs) > 0 {
+		t.Error("expected no error")
+	}
+
+	if errs := Time(input).Parse(); len(errs) == 0 || errs[0].Code != CodeRequired {
+		t.Error("expected required error")
+	}
+}
+
+func TestDuration(t *testing.T) {
+	timeout := 90 * time.Second
+	var zero time.Duration
+
+	if errs := Duration(&timeout).Min(time.Second).Max(time.Minute).Parse(); len(errs) == 0 || errs[0].Code != CodeMax {
+		t.Error("expected max error")
+	}
+
+	if errs := Duration(&timeout).Truncate(time.Minute).Between(time.Second, time.Minute, Inclusive).Parse(); len(errs) > 0 {
+		t.Error("expected no error")
+	}
+
+	if timeout != time.Minute {
+		t.Error("input not transformed properly")
+	}
+
+	if errs := Duration(&zero).NotZero().Parse(); len(errs) == 0 || errs[0].Code != CodeNotZero {
+		t.Error("expected not-zero error")
+	}
+}
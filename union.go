@@ -0,0 +1,128 @@
+package validator
+
+import (
+	"context"
+	"slices"
+)
+
+// snapshotter is implemented by the fields that can save the values they are bound to, see fieldCore.snapshot
+type snapshotter interface {
+	snapshot() (restore func())
+}
+
+// snapshotField saves the value of 'field', if it can.
+func snapshotField(field Field) (restore func()) {
+	if s, ok := field.(snapshotter); ok {
+		return s.snapshot()
+	}
+
+	return func() {}
+}
+
+type oneOfField struct {
+	fields []Field
+}
+
+func (f oneOfField) ParseTo(ctx context.Context, errs *Collector) bool {
+	attempts := make([]Collector, len(f.fields))
+	for i, field := range f.fields {
+		if errs.Done(ctx) {
+			return false
+		}
+
+		restore := snapshotField(field)
+		attempts[i].limit = errs.remaining()
+		if field.ParseTo(ctx, &attempts[i]) {
+			return true
+		}
+		restore()
+	}
+
+	errs.Add(actionError("", ruleMessage(nil, CodeNoMatch, "", map[string]any{"schemas": len(f.fields)}), CodeNoMatch))
+	for i := range attempts {
+		errs.merge(&attempts[i])
+	}
+
+	return false
+}
+
+func (f oneOfField) exclusive() bool {
+	for _, field := range f.fields {
+		if e, ok := field.(exclusiveField); ok && e.exclusive() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (f oneOfField) snapshot() (restore func()) {
+	restores := make([]func(), len(f.fields))
+	for i, field := range f.fields {
+		restores[i] = snapshotField(field)
+	}
+
+	return func() {
+		for _, restore := range restores {
+			restore()
+		}
+	}
+}
+
+// OneOf parses the fields in order and stops at the first one without errors.
+// If none of them matches, a CodeNoMatch error is reported, followed by the errors of every field.
+// Use it in StructField.Fields. The values of a field that didn't match are restored before the next field is parsed,
+// so that its transformations don't reach the other fields. Custom fields are not restored.
+func OneOf(fields ...Field) Field {
+	return oneOfField{fields: fields}
+}
+
+type discriminatedField struct {
+	name          string
+	discriminator func() string
+	fields        map[string]Field
+}
+
+func (f discriminatedField) ParseTo(ctx context.Context, errs *Collector) bool {
+	value := f.discriminator()
+	field, ok := f.fields[value]
+	if !ok {
+		allowed := make([]string, 0, len(f.fields))
+		for key := range f.fields {
+			allowed = append(allowed, key)
+		}
+		slices.Sort(allowed)
+
+		params := map[string]any{"value": value, "allowed": allowed}
+		errs.Add(actionError(f.name, ruleMessage(nil, CodeUnknownDiscriminator, f.name, params), CodeUnknownDiscriminator))
+		return false
+	}
+
+	return field.ParseTo(ctx, errs)
+}
+
+// exclusive is always true, since the discriminator reads another field, see exclusiveField.
+func (f discriminatedField) exclusive() bool {
+	return true
+}
+
+func (f discriminatedField) snapshot() (restore func()) {
+	if field, ok := f.fields[f.discriminator()]; ok {
+		return snapshotField(field)
+	}
+
+	return func() {}
+}
+
+// Discriminated parses the field that 'fields' holds for the value returned by 'discriminator',
+// for example the 'type' of a polymorphic payload. Use it in StructField.Fields.
+// An unknown value is reported with CodeUnknownDiscriminator for the field named 'name', which holds the discriminator.
+// Even if multiple values are passed for 'name', only the first value will be considered.
+func Discriminated(discriminator func() string, fields map[string]Field, name ...string) Field {
+	f := discriminatedField{discriminator: discriminator, fields: fields}
+	if len(name) > 0 {
+		f.name = name[0]
+	}
+
+	return f
+}
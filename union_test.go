@@ -0,0 +1,148 @@
+package validator
+
+import "testing"
+
+func TestOneOf(t *testing.T) {
+
+	type Contact struct {
+		email string
+		phone string
+	}
+
+	contact := Contact{phone: "9876543210"}
+	schema := Struct(&contact, "contact").Fields(
+		OneOf(
+			String(&contact.email, "email").Email(),
+			String(&contact.phone, "phone").Numeric().Length(10),
+		),
+	)
+
+	if errs := schema.Parse(); len(errs) > 0 {
+		t.Errorf("expected the phone to match, got %v", errs)
+	}
+
+	contact.phone = "98765"
+	errs := schema.Parse()
+	if len(errs) != 3 {
+		t.Fatalf("expected a no-match error followed by 2 errors, got %v", errs)
+	}
+
+	if errs[0].Field != "contact" || errs[0].Code != CodeNoMatch || errs[0].Params["schemas"] != 2 {
+		t.Errorf("unexpected error %v", errs[0])
+	}
+
+	if errs[1].Field != "contact.email" || errs[2].Field != "contact.phone" || errs[2].Code != CodeLength {
+		t.Errorf("expected the errors of both fields, got %v", errs[1:])
+	}
+}
+
+func TestOneOfRestoresTransforms(t *testing.T) {
+	input := "  abc  "
+	errs := Struct[struct{}](&struct{}{}).Fields(
+		OneOf(
+			String(&input, "input").TrimSpace().Length(100),
+			String(&input, "input").StartsWith("  "),
+		),
+	).Parse()
+
+	if len(errs) > 0 || input != "  abc  " {
+		t.Errorf("expected the second field to match the untrimmed value, got %q %v", input, errs)
+	}
+
+	type Order struct {
+		tags []string
+	}
+
+	order := Order{tags: []string{" a ", " b "}}
+	errs = Struct(&order, "order").Fields(
+		OneOf(
+			Struct(&order, "order").Fields(Slice(&order.tags, "tags").Each(String(nil).TrimSpace().Min(2))),
+			Slice(&order.tags, "tags").Each(String(nil).Length(3)),
+		),
+	).Parse()
+
+	if len(errs) > 0 || order.tags[0] != " a " {
+		t.Errorf("expected the elements of the slice to be restored, got %q %v", order.tags, errs)
+	}
+}
+
+func TestOneOfMaxErrors(t *testing.T) {
+	name, phone := "a", "12"
+	calls := 0
+	counted := func(string) error {
+		calls++
+		return nil
+	}
+
+	Struct[struct{}](&struct{}{}).Fields(
+		String(&name, "name").Min(3),
+		OneOf(String(&phone, "phone").Min(5).Refine(counted)),
+	).Parse(WithMaxErrors(2))
+
+	if calls != 0 {
+		t.Errorf("expected the field to stop at the remaining limit, got %d calls", calls)
+	}
+}
+
+func TestDiscriminated(t *testing.T) {
+
+	type Payment struct {
+		amount int
+	}
+
+	type Refund struct {
+		reason string
+	}
+
+	type Event struct {
+		kind    string
+		payment Payment
+		refund  Refund
+	}
+
+	event := Event{kind: "refund"}
+	schema := Struct(&event, "event").Fields(
+		Discriminated(func() string { return event.kind }, map[string]Field{
+			"payment": Struct(&event.payment, "payment").Fields(Number(&event.payment.amount, "amount").Positive()),
+			"refund":  Struct(&event.refund, "refund").Fields(String(&event.refund.reason, "reason").Min(3)),
+		}, "type"),
+	)
+
+	errs := schema.Parse()
+	if len(errs) != 1 || errs[0].Field != "event.refund.reason" {
+		t.Errorf("expected only the refund to be parsed, got %v", errs)
+	}
+
+	event.kind = "chargeback"
+	errs = schema.Parse()
+	if len(errs) != 1 || errs[0].Field != "event.type" || errs[0].Code != CodeUnknownDiscriminator {
+		t.Fatalf("expected an unknown discriminator error, got %v", errs)
+	}
+
+	if errs[0].Message != "chargeback is not a known type, expected one of payment, refund" {
+		t.Errorf("unexpected message %q", errs[0].Message)
+	}
+}
+
+func TestDiscriminatedConcurrent(t *testing.T) {
+
+	type Event struct {
+		kind   string
+		amount int
+	}
+
+	event := Event{kind: "  PAYMENT  "}
+	errs := Struct(&event, "event").
+		Fields(
+			String(&event.kind, "type").TrimSpace().ToLowerCase(),
+			Discriminated(func() string { return event.kind }, map[string]Field{
+				"payment": Number(&event.amount, "amount").Positive(),
+			}, "type"),
+		).
+		Concurrent(4).
+		Parse()
+
+	if len(errs) != 1 || errs[0].Field != "event.amount" {
+		t.Errorf("expected the discriminator to see the transformed type, got %v", errs)
+	}
+}
@@ -0,0 +1,64 @@
+package validator
+
+import (
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// URLOptions restricts the URLs accepted by StringField.URLWith.
+// The zero value accepts any absolute URL, with or without a host.
+type URLOptions struct {
+	// Schemes lists the accepted schemes, for example "https". Any scheme is accepted if it is empty.
+	Schemes []string
+	// RequireHost rejects URLs without a host, such as "mailto:me@mail.com"
+	RequireHost bool
+	// ForbidCredentials rejects URLs with a username or password
+	ForbidCredentials bool
+	// ForbidPort rejects URLs with an explicit port
+	ForbidPort bool
+	// ForbidQuery rejects URLs with a query string
+	ForbidQuery bool
+	// ForbidFragment rejects URLs with a fragment
+	ForbidFragment bool
+}
+
+// checkURL returns a ruleError describing the first option the URL does not satisfy.
+func checkURL(name, value string, opts URLOptions) error {
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme == "" || strings.ContainsAny(value, " \t\r\n") {
+		return ruleError{code: CodeURL, message: message{key: CodeURL, name: name, params: nil}}
+	}
+
+	if len(opts.Schemes) > 0 && !slices.ContainsFunc(opts.Schemes, func(s string) bool { return strings.EqualFold(s, u.Scheme) }) {
+		return ruleError{code: CodeURLScheme, message: message{key: CodeURLScheme, name: name, params: map[string]any{"schemes": opts.Schemes}}}
+	}
+
+	if opts.RequireHost && u.Hostname() == "" {
+		return ruleError{code: CodeURLHost, message: message{key: CodeURLHost, name: name, params: nil}}
+	}
+
+	if opts.ForbidCredentials && u.User != nil {
+		return ruleError{code: CodeURLCredentials, message: message{key: CodeURLCredentials, name: name, params: nil}}
+	}
+
+	if port := u.Port(); port != "" {
+		if opts.ForbidPort {
+			return ruleError{code: CodeURLPort, message: message{key: CodeURLPort + ".forbidden", name: name, params: nil}}
+		}
+		if n, err := strconv.Atoi(port); err != nil || n > 65535 {
+			return ruleError{code: CodeURLPort, message: message{key: CodeURLPort, name: name, params: nil}}
+		}
+	}
+
+	if opts.ForbidQuery && (u.RawQuery != "" || u.ForceQuery) {
+		return ruleError{code: CodeURLQuery, message: message{key: CodeURLQuery, name: name, params: nil}}
+	}
+
+	if opts.ForbidFragment && (u.Fragment != "" || strings.HasSuffix(value, "#")) {
+		return ruleError{code: CodeURLFragment, message: message{key: CodeURLFragment, name: name, params: nil}}
+	}
+
+	return nil
+}